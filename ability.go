@@ -0,0 +1,34 @@
+package main
+
+import (
+	"context"
+	"strings"
+
+	"github.com/anegri01f01/pokegocli/internal/i18n"
+)
+
+func commandAbility(ctx context.Context, config *Config) (any, error) {
+	ability, err := config.Client.GetAbility(ctx, strings.ToLower(config.Args[0]))
+	if err != nil {
+		return nil, err
+	}
+	names := newNamer(ctx, config)
+	result := abilityResult{Name: ability.Name, Generation: ability.Generation.Name, Pokemon: []abilityHolder{}, lang: names.lang}
+effect:
+	for _, l := range []string{names.lang, i18n.English} {
+		for _, e := range ability.EffectEntries {
+			if e.Language.Name == l {
+				result.Effect = e.Effect
+				break effect
+			}
+		}
+	}
+	for _, p := range ability.Pokemon {
+		result.Pokemon = append(result.Pokemon, abilityHolder{p.Pokemon.Name, p.IsHidden})
+	}
+	if !names.english() {
+		names.add("ability", ability.Name, ability.Names)
+		result.Labels = names.labels
+	}
+	return result, nil
+}
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/anegri01f01/pokegocli/internal/i18n"
+)
+
+// argDef describes a positional argument of a command.
+type argDef struct {
+	name     string
+	optional bool
+	variadic bool
+}
+
+// flagDef describes a --name option of a command, optionally also spelled
+// -short. Flags without a value placeholder are booleans.
+type flagDef struct {
+	name  string
+	short string
+	value string
+	usage string
+}
+
+// commonFlags are accepted by every command.
+var commonFlags = []flagDef{
+	{name: "output", short: "o", value: "format", usage: "print the result as text, table, json or yaml"},
+	{name: "lang", value: "language", usage: "show names and messages in a language, e.g. ja, fr or de"},
+}
+
+// usageError reports a command invoked with the wrong arguments.
+type usageError struct {
+	cmd cliCommand
+	msg string
+}
+
+func (e *usageError) Error() string {
+	return e.msg + "\nusage: " + e.cmd.usage()
+}
+
+func (cmd cliCommand) usage() string {
+	parts := []string{cmd.name}
+	for _, arg := range cmd.args {
+		name := arg.name
+		if arg.variadic {
+			name += "..."
+		}
+		if arg.optional {
+			parts = append(parts, "["+name+"]")
+		} else {
+			parts = append(parts, "<"+name+">")
+		}
+	}
+	if len(cmd.flags) > 0 {
+		parts = append(parts, "[options]")
+	}
+	return strings.Join(parts, " ")
+}
+
+func (f flagDef) usageName() string {
+	name := "--" + f.name
+	if f.short != "" {
+		name = "-" + f.short + ", " + name
+	}
+	if f.value == "" {
+		return name
+	}
+	return name + " <" + f.value + ">"
+}
+
+// flag looks up an option of the command, or a common one, by its long or
+// short name.
+func (cmd cliCommand) flag(name string) (flagDef, bool) {
+	for _, defs := range [][]flagDef{cmd.flags, commonFlags} {
+		for _, f := range defs {
+			if f.name == name || (f.short != "" && f.short == name) {
+				return f, true
+			}
+		}
+	}
+	return flagDef{}, false
+}
+
+// parseArgs splits tokens into positional arguments and flags according to
+// the command's definition, keyed by the flags' long names. Everything
+// after "--" is positional, as is any -x that is not a known flag. Errors
+// are written in lang.
+func parseArgs(lang string, cmd cliCommand, tokens []string) ([]string, map[string]string, error) {
+	var args []string
+	flags := make(map[string]string)
+
+	for i := 0; i < len(tokens); i++ {
+		tok := tokens[i]
+		if tok == "--" {
+			args = append(args, tokens[i+1:]...)
+			break
+		}
+		if !strings.HasPrefix(tok, "-") || tok == "-" {
+			args = append(args, tok)
+			continue
+		}
+
+		long := strings.HasPrefix(tok, "--")
+		name, value, hasValue := strings.Cut(strings.TrimLeft(tok, "-"), "=")
+		def, ok := cmd.flag(name)
+		if !long && !ok {
+			args = append(args, tok)
+			continue
+		}
+		if !ok {
+			return nil, nil, &usageError{cmd, i18n.Sprintf(lang, "unknown option --%s", name)}
+		}
+		name = def.name
+		switch {
+		case def.value == "" && hasValue:
+			return nil, nil, &usageError{cmd, i18n.Sprintf(lang, "option --%s does not take a value", name)}
+		case def.value == "":
+			value = "true"
+		case !hasValue:
+			if i+1 >= len(tokens) {
+				return nil, nil, &usageError{cmd, i18n.Sprintf(lang, "option --%s needs a value", name)}
+			}
+			i++
+			value = tokens[i]
+		}
+		flags[name] = value
+	}
+
+	required, variadic := 0, false
+	for _, arg := range cmd.args {
+		if !arg.optional {
+			required++
+		}
+		variadic = variadic || arg.variadic
+	}
+	if len(args) < required {
+		return nil, nil, &usageError{cmd, i18n.Sprintf(lang, "%s needs %d argument(s), got %d", cmd.name, required, len(args))}
+	}
+	if !variadic && len(args) > len(cmd.args) {
+		return nil, nil, &usageError{cmd, i18n.Sprintf(lang, "%s takes at most %d argument(s), got %d", cmd.name, len(cmd.args), len(args))}
+	}
+	return args, flags, nil
+}
+
+var errUnterminatedQuote = errors.New("unterminated quote")
+
+// cleanInput splits a command line on whitespace, honoring single and
+// double quotes and backslash escapes outside single quotes.
+func cleanInput(line string) ([]string, error) {
+	var words []string
+	var word strings.Builder
+	inWord := false
+	var quote rune
+
+	runes := []rune(line)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != '\'' && r == '\\' && i+1 < len(runes):
+			i++
+			word.WriteRune(runes[i])
+			inWord = true
+		case quote != 0:
+			word.WriteRune(r)
+		case r == '"' || r == '\'':
+			quote = r
+			inWord = true
+		case r == ' ' || r == '\t':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+	if quote != 0 {
+		return nil, errUnterminatedQuote
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
+
+// sortedCommands returns the registered commands ordered by name.
+func sortedCommands() []cliCommand {
+	cmds := make([]cliCommand, 0, len(cmdRegistry))
+	for _, cmd := range cmdRegistry {
+		cmds = append(cmds, cmd)
+	}
+	sort.Slice(cmds, func(i, j int) bool { return cmds[i].name < cmds[j].name })
+	return cmds
+}
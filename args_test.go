@@ -0,0 +1,82 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/anegri01f01/pokegocli/internal/i18n"
+)
+
+func TestParseArgs(t *testing.T) {
+	cmd := cliCommand{
+		name: "explore",
+		args: []argDef{{name: "area"}, {name: "page", optional: true}},
+		flags: []flagDef{
+			{name: "version", value: "name"},
+			{name: "all"},
+		},
+	}
+
+	cases := []struct {
+		tokens []string
+		args   []string
+		flags  map[string]string
+	}{
+		{
+			tokens: []string{"canalave-city-area"},
+			args:   []string{"canalave-city-area"},
+			flags:  map[string]string{},
+		},
+		{
+			tokens: []string{"--version", "diamond", "canalave-city-area", "--all"},
+			args:   []string{"canalave-city-area"},
+			flags:  map[string]string{"version": "diamond", "all": "true"},
+		},
+		{
+			tokens: []string{"canalave-city-area", "--version=pearl", "--", "--all"},
+			args:   []string{"canalave-city-area", "--all"},
+			flags:  map[string]string{"version": "pearl"},
+		},
+		{
+			tokens: []string{"-o", "json", "-5", "canalave-city-area"},
+			args:   []string{"-5", "canalave-city-area"},
+			flags:  map[string]string{"output": "json"},
+		},
+	}
+	for _, c := range cases {
+		args, flags, err := parseArgs(i18n.English, cmd, c.tokens)
+		if err != nil {
+			t.Errorf("parseArgs(%q): %v", c.tokens, err)
+			continue
+		}
+		if !reflect.DeepEqual(args, c.args) || !reflect.DeepEqual(flags, c.flags) {
+			t.Errorf("parseArgs(%q) = %q, %v; want %q, %v", c.tokens, args, flags, c.args, c.flags)
+		}
+	}
+
+	for _, bad := range [][]string{
+		{},
+		{"a", "b", "c"},
+		{"a", "--unknown"},
+		{"a", "--version"},
+		{"a", "--all=yes"},
+	} {
+		_, _, err := parseArgs(i18n.English, cmd, bad)
+		var usageErr *usageError
+		if !errors.As(err, &usageErr) {
+			t.Errorf("parseArgs(%q): expected a usage error, got %v", bad, err)
+		}
+	}
+}
+
+func TestCommandUsage(t *testing.T) {
+	cmd := cliCommand{
+		name:  "prefetch",
+		args:  []argDef{{name: "areas|pokemon", optional: true}, {name: "extra", optional: true, variadic: true}},
+		flags: []flagDef{{name: "force"}},
+	}
+	if got, want := cmd.usage(), "prefetch [areas|pokemon] [extra...] [options]"; got != want {
+		t.Errorf("usage() = %q, want %q", got, want)
+	}
+}
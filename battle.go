@@ -0,0 +1,308 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/anegri01f01/pokegocli/internal/battle"
+	"github.com/anegri01f01/pokegocli/internal/encounter"
+	"github.com/anegri01f01/pokegocli/internal/i18n"
+	"github.com/anegri01f01/pokegocli/internal/pokeapi"
+	"github.com/anegri01f01/pokegocli/internal/pokedex"
+	"github.com/anegri01f01/pokegocli/internal/stats"
+)
+
+// maxTeam is how many Pokemon a trainer takes into battle.
+const maxTeam = 6
+
+// battleFriendship is how much friendship a battle won adds.
+const battleFriendship = 5
+
+// maxAutoTurns stops battle --auto when neither side can win, e.g. two
+// Pokemon that only know splash.
+const maxAutoTurns = 100
+
+func commandBattle(ctx context.Context, config *Config) (any, error) {
+	session := &config.Pokedex.Session
+	auto := config.Flags["auto"] == "true"
+	if b := session.Battle; b != nil {
+		if _, ok := config.Flags["team"]; ok || config.Flags["trainer"] == "true" {
+			return nil, errors.New("you are already in a battle, use fight, switch or flee")
+		}
+		var log []string
+		if auto {
+			eff, err := typeEffectiveness(ctx, config)
+			if err != nil {
+				return nil, err
+			}
+			log = b.Auto(language(config), config.Rand, eff, maxAutoTurns)
+		}
+		return endTurn(config, b, log)
+	}
+
+	team, err := battleTeam(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	b := &battle.Battle{Kind: battle.Wild, Player: battle.Side{Team: team}}
+	if config.Flags["trainer"] == "true" {
+		b.Kind = battle.Trainer
+		b.Trainer, b.Opponent.Team, err = trainerTeam(ctx, config)
+	} else {
+		b.Opponent.Team, err = wildTeam(ctx, config)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	log := b.Start(language(config))
+	if auto {
+		eff, err := typeEffectiveness(ctx, config)
+		if err != nil {
+			return nil, err
+		}
+		log = append(log, b.Auto(language(config), config.Rand, eff, maxAutoTurns)...)
+	}
+	session.Battle = b
+	return endTurn(config, b, log)
+}
+
+func commandFight(ctx context.Context, config *Config) (any, error) {
+	b, err := currentBattle(config)
+	if err != nil {
+		return nil, err
+	}
+	fighter := b.Player.Fighter()
+	index, ok := fighter.MoveIndex(config.Args[0])
+	if n, err := strconv.Atoi(config.Args[0]); err == nil && n >= 1 && n <= len(fighter.Moves) {
+		index, ok = n-1, true
+	}
+	if !ok {
+		var moves []string
+		for _, m := range fighter.Moves {
+			moves = append(moves, m.Name)
+		}
+		return nil, errors.New(fighter.DisplayName() + " does not know " + config.Args[0] + ", only " + strings.Join(moves, ", "))
+	}
+	return playTurn(ctx, config, b, battle.Action{Kind: battle.Fight, Index: index})
+}
+
+func commandSwitch(ctx context.Context, config *Config) (any, error) {
+	b, err := currentBattle(config)
+	if err != nil {
+		return nil, err
+	}
+	id, err := parseID(config.Args[0])
+	if err != nil {
+		return nil, err
+	}
+	index := slices.IndexFunc(b.Player.Team, func(f *battle.Fighter) bool { return f.ID == id })
+	switch {
+	case index < 0:
+		return nil, errors.New("#" + strconv.Itoa(id) + " is not in your team")
+	case index == b.Player.Active:
+		return nil, errors.New(b.Player.Fighter().DisplayName() + " is already battling")
+	case b.Player.Team[index].Fainted():
+		return nil, errors.New(b.Player.Team[index].DisplayName() + " has fainted and cannot battle")
+	}
+	return playTurn(ctx, config, b, battle.Action{Kind: battle.Switch, Index: index})
+}
+
+func commandFlee(ctx context.Context, config *Config) (any, error) {
+	b, err := currentBattle(config)
+	if err != nil {
+		return nil, err
+	}
+	if b.Kind == battle.Trainer {
+		return nil, errors.New("there's no running from a trainer battle")
+	}
+	return playTurn(ctx, config, b, battle.Action{Kind: battle.Flee})
+}
+
+func currentBattle(config *Config) (*battle.Battle, error) {
+	if config.Pokedex.Session.Battle == nil {
+		return nil, errors.New("you are not in a battle, start one with battle")
+	}
+	return config.Pokedex.Session.Battle, nil
+}
+
+// notInBattle refuses commands that would leave a battle behind.
+func notInBattle(config *Config) error {
+	if config.Pokedex.Session.Battle != nil {
+		return errors.New("you are in a battle, use fight, switch or flee")
+	}
+	return nil
+}
+
+func playTurn(ctx context.Context, config *Config, b *battle.Battle, action battle.Action) (any, error) {
+	eff, err := typeEffectiveness(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	return endTurn(config, b, b.Turn(language(config), config.Rand, eff, action))
+}
+
+// endTurn saves the battle, or ends it once it is over, and describes it.
+func endTurn(config *Config, b *battle.Battle, log []string) (battleResult, error) {
+	finishBattle(config, b)
+	if err := config.Pokedex.Save(config.PokedexPath); err != nil {
+		return battleResult{}, err
+	}
+	return newBattleResult(config, b, log), nil
+}
+
+// finishBattle ends the session's battle once it is over. A wild Pokemon
+// is gone after the battle, whatever its outcome. Team members still
+// standing after a win grow friendlier.
+func finishBattle(config *Config, b *battle.Battle) {
+	if b.Outcome == "" {
+		return
+	}
+	session := &config.Pokedex.Session
+	session.Battle = nil
+	if b.Kind == battle.Wild {
+		session.Encounter = nil
+	}
+	if b.Outcome != battle.Won {
+		return
+	}
+	for _, f := range b.Player.Team {
+		if caught, ok := config.Pokedex.Get(f.ID); ok && !f.Fainted() {
+			caught.Friendship = min(caught.Friendship+battleFriendship, pokedex.MaxFriendship)
+		}
+	}
+}
+
+// battleTeam prepares the Pokemon chosen with --team, or the strongest
+// ones caught.
+func battleTeam(ctx context.Context, config *Config) ([]*battle.Fighter, error) {
+	var ids []int
+	if val, ok := config.Flags["team"]; ok {
+		for _, arg := range strings.Split(val, ",") {
+			id, err := parseID(strings.TrimSpace(arg))
+			if err != nil {
+				return nil, &usageError{cmdRegistry["battle"], err.Error()}
+			}
+			if !slices.Contains(ids, id) {
+				ids = append(ids, id)
+			}
+		}
+		if len(ids) > maxTeam {
+			return nil, &usageError{cmdRegistry["battle"], i18n.Sprintf(language(config), "a team has at most %d pokemon", maxTeam)}
+		}
+	} else {
+		caught := slices.Clone(config.Pokedex.Caught)
+		sort.SliceStable(caught, func(i, j int) bool { return caught[i].Level > caught[j].Level })
+		for i := 0; i < len(caught) && i < maxTeam; i++ {
+			ids = append(ids, caught[i].ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, errors.New("you have no pokemon to battle with, catch one first")
+	}
+
+	var team []*battle.Fighter
+	for _, id := range ids {
+		caught, ok := config.Pokedex.Get(id)
+		if !ok {
+			return nil, errors.New("you have no pokemon #" + strconv.Itoa(id))
+		}
+		nature, err := stats.LookupNature(caught.Nature)
+		if err != nil {
+			nature = stats.Natures[0]
+		}
+		f, err := newFighter(ctx, config, config.Pokedex.Pokemon[caught.Name], caught.Level, caught.IVs, nature)
+		if err != nil {
+			return nil, err
+		}
+		f.ID, f.Nickname = caught.ID, caught.Nickname
+		team = append(team, f)
+	}
+	return team, nil
+}
+
+// wildTeam prepares the wild Pokemon being faced.
+func wildTeam(ctx context.Context, config *Config) ([]*battle.Fighter, error) {
+	wild := config.Pokedex.Session.Encounter
+	if wild == nil {
+		return nil, errors.New("there is no wild pokemon in front of you, use encounter to look for one or battle --trainer")
+	}
+	data, err := config.Client.GetPokemon(ctx, wild.Pokemon)
+	if err != nil {
+		return nil, err
+	}
+	f, err := newFighter(ctx, config, data, wild.Level, stats.RollIVs(config.Rand), stats.RollNature(config.Rand))
+	if err != nil {
+		return nil, err
+	}
+	return []*battle.Fighter{f}, nil
+}
+
+// trainerTeam makes up a trainer of the current area with one to three
+// of the Pokemon that live there.
+func trainerTeam(ctx context.Context, config *Config) (string, []*battle.Fighter, error) {
+	session := config.Pokedex.Session
+	if session.Area == "" {
+		return "", nil, errors.New("you are nowhere yet, explore an area first")
+	}
+	area, err := config.Client.GetLocationArea(ctx, session.Area)
+	if err != nil {
+		return "", nil, err
+	}
+	noTrainers := errors.New("no trainers battle in " + session.Area + " with " + session.Method + " in " + session.Version)
+	slots := encounter.Slots(area, session.Version, session.Method)
+	if len(slots) == 0 {
+		return "", nil, noTrainers
+	}
+
+	var team []*battle.Fighter
+	for n := 1 + config.Rand.Intn(3); len(team) < n; {
+		wild, ok := encounter.Roll(config.Rand, slots)
+		if !ok {
+			return "", nil, noTrainers
+		}
+		data, err := config.Client.GetPokemon(ctx, wild.Pokemon)
+		if err != nil {
+			return "", nil, err
+		}
+		f, err := newFighter(ctx, config, data, wild.Level, stats.RollIVs(config.Rand), stats.RollNature(config.Rand))
+		if err != nil {
+			return "", nil, err
+		}
+		team = append(team, f)
+	}
+	return trainerClass(session.Method), team, nil
+}
+
+// trainerClass names a trainer after how the area is explored.
+func trainerClass(method string) string {
+	switch {
+	case strings.HasSuffix(method, "-rod"):
+		return "Fisherman"
+	case method == "surf":
+		return "Swimmer"
+	}
+	return "Youngster"
+}
+
+// newFighter prepares a Pokemon for battle with the moves it would know
+// at its level, or tackle when it knows none.
+func newFighter(ctx context.Context, config *Config, data pokeapi.Pokemon, level int, ivs stats.Stats, nature stats.Nature) (*battle.Fighter, error) {
+	names := battle.LevelUpMoves(data, level)
+	if len(names) == 0 {
+		names = []string{"tackle"}
+	}
+	var moves []battle.Move
+	for _, name := range names {
+		move, err := config.Client.GetMove(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		moves = append(moves, battle.NewMove(move))
+	}
+	return battle.NewFighter(data, level, ivs, nature, moves), nil
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/anegri01f01/pokegocli/internal/i18n"
+	"github.com/anegri01f01/pokegocli/internal/pokedex"
+	"github.com/anegri01f01/pokegocli/internal/stats"
+)
+
+// parseID reads a caught Pokemon's ID, written 3 or #3.
+func parseID(arg string) (int, error) {
+	id, err := strconv.Atoi(strings.TrimPrefix(arg, "#"))
+	if err != nil || id < 1 {
+		return 0, errors.New(arg + " is not a pokemon ID, see list")
+	}
+	return id, nil
+}
+
+// caughtByID looks up the caught Pokemon named by the argument.
+func caughtByID(config *Config, arg string) (*pokedex.CaughtPokemon, error) {
+	id, err := parseID(arg)
+	if err != nil {
+		return nil, err
+	}
+	caught, ok := config.Pokedex.Get(id)
+	if !ok {
+		return nil, errors.New("you have no pokemon #" + strconv.Itoa(id))
+	}
+	return caught, nil
+}
+
+func commandList(ctx context.Context, config *Config) (any, error) {
+	result := listResult{Pokemon: []caughtSummary{}, lang: language(config)}
+	for _, c := range config.Pokedex.Caught {
+		if len(config.Args) > 0 && c.Name != strings.ToLower(config.Args[0]) {
+			continue
+		}
+		result.Pokemon = append(result.Pokemon, summarize(c))
+	}
+	return result, nil
+}
+
+func commandRename(ctx context.Context, config *Config) (any, error) {
+	caught, err := caughtByID(config, config.Args[0])
+	if err != nil {
+		return nil, err
+	}
+	caught.Nickname = strings.TrimSpace(config.Args[1])
+	if err := config.Pokedex.Save(config.PokedexPath); err != nil {
+		return nil, err
+	}
+	if caught.Nickname == "" {
+		return messageResult{i18n.Sprintf(language(config), "#%d is called %s again", caught.ID, caught.Name)}, nil
+	}
+	return messageResult{i18n.Sprintf(language(config), "%s #%d is now called %s", caught.Name, caught.ID, caught.Nickname)}, nil
+}
+
+func commandRelease(ctx context.Context, config *Config) (any, error) {
+	if err := notInBattle(config); err != nil {
+		return nil, err
+	}
+	caught, err := caughtByID(config, config.Args[0])
+	if err != nil {
+		return nil, err
+	}
+	name := caught.DisplayName()
+	config.Pokedex.Release(caught.ID)
+	if err := config.Pokedex.Save(config.PokedexPath); err != nil {
+		return nil, err
+	}
+	return messageResult{i18n.Sprintf(language(config), "%s was released. Bye, %s!", name, name)}, nil
+}
+
+// inspectCaught describes one caught Pokemon with its actual stats.
+func inspectCaught(ctx context.Context, config *Config, id int) (any, error) {
+	caught, ok := config.Pokedex.Get(id)
+	if !ok {
+		return nil, errors.New("you have no pokemon #" + strconv.Itoa(id))
+	}
+	data := config.Pokedex.Pokemon[caught.Name]
+	if caught.Gender == "" {
+		// Pokemon migrated from files that kept no gender get one now.
+		species, err := config.Client.GetPokemonSpecies(ctx, data.Species.Name)
+		if err != nil {
+			return nil, err
+		}
+		caught.Gender = stats.RollGender(config.Rand, species.GenderRate)
+		if err := config.Pokedex.Save(config.PokedexPath); err != nil {
+			return nil, err
+		}
+	}
+	nature, err := stats.LookupNature(caught.Nature)
+	if err != nil {
+		nature = stats.Natures[0]
+	}
+
+	result := caughtResult{
+		caughtSummary: summarize(*caught),
+		Ability:       caught.Ability,
+		Friendship:    caught.Friendship,
+		Ball:          caught.Ball,
+		CaughtAt:      caught.CaughtAt,
+		Location:      caught.Location,
+		IVs:           caught.IVs,
+		Stats:         stats.Compute(stats.Base(data), caught.IVs, caught.Level, nature),
+		Types:         []string{},
+	}
+	for _, t := range data.Types {
+		result.Types = append(result.Types, t.Type.Name)
+	}
+	for _, a := range data.Abilities {
+		if a.Ability.Name == caught.Ability {
+			result.HiddenAbility = a.IsHidden
+		}
+	}
+
+	names := newNamer(ctx, config)
+	result.lang = names.lang
+	for _, stat := range stats.Names {
+		if err := names.stat(stat); err != nil {
+			return nil, err
+		}
+	}
+	for _, typ := range result.Types {
+		if err := names.typ(typ); err != nil {
+			return nil, err
+		}
+	}
+	if caught.Ability != "" {
+		if err := names.ability(caught.Ability); err != nil {
+			return nil, err
+		}
+	}
+	result.Labels = names.labels
+	return result, nil
+}
+
+func summarize(c pokedex.CaughtPokemon) caughtSummary {
+	return caughtSummary{
+		ID:       c.ID,
+		Name:     c.Name,
+		Nickname: c.Nickname,
+		Level:    c.Level,
+		Nature:   c.Nature,
+		Gender:   c.Gender,
+		Shiny:    c.Shiny,
+	}
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/anegri01f01/pokegocli/internal/lineedit"
+)
+
+// completeLine offers command names for the first word and, for the
+// argument of a command, names the session has seen: areas from the last
+// map page for explore, the wild Pokemon for catch, caught Pokemon for
+// inspect, the abilities of caught species for ability and the moves and
+// team of the current battle.
+func completeLine(conf *Config) lineedit.Completer {
+	return func(line string, pos int) (int, []string) {
+		before := line[:pos]
+		start := strings.LastIndexAny(before, " \t") + 1
+		word := before[start:]
+
+		words, err := cleanInput(before[:start])
+		if err != nil {
+			return start, nil
+		}
+
+		var options []string
+		switch {
+		case len(words) == 0:
+			options = commandNames()
+		case strings.HasPrefix(word, "-"):
+			for _, f := range cmdRegistry[strings.ToLower(words[0])].flags {
+				options = append(options, "--"+f.name)
+			}
+			for _, f := range commonFlags {
+				options = append(options, "--"+f.name)
+			}
+		default:
+			options = argumentNames(conf, strings.ToLower(words[0]))
+		}
+
+		var matches []string
+		for _, option := range options {
+			if strings.HasPrefix(option, word) {
+				matches = append(matches, option)
+			}
+		}
+		sort.Strings(matches)
+		return start, matches
+	}
+}
+
+func commandNames() []string {
+	names := make([]string, 0, len(cmdRegistry))
+	for name := range cmdRegistry {
+		names = append(names, name)
+	}
+	return names
+}
+
+func argumentNames(conf *Config, command string) []string {
+	switch command {
+	case "explore":
+		return conf.LastAreas
+	case "catch":
+		if wild := conf.Pokedex.Session.Encounter; wild != nil {
+			return []string{wild.Pokemon}
+		}
+	case "inspect", "evolution", "moves":
+		return append(conf.Pokedex.Names(), caughtIDs(conf)...)
+	case "rename", "release", "evolve":
+		return caughtIDs(conf)
+	case "matchup":
+		return append(conf.Pokedex.Names(), caughtIDs(conf)...)
+	case "ability":
+		var abilities []string
+		for _, data := range conf.Pokedex.Pokemon {
+			for _, a := range data.Abilities {
+				if !slices.Contains(abilities, a.Ability.Name) {
+					abilities = append(abilities, a.Ability.Name)
+				}
+			}
+		}
+		return abilities
+	case "types":
+		if conf.types != nil {
+			return conf.types.Types()
+		}
+	case "fight":
+		if b := conf.Pokedex.Session.Battle; b != nil {
+			return newCombatant(b.Player.Fighter()).Moves
+		}
+	case "switch":
+		if b := conf.Pokedex.Session.Battle; b != nil {
+			var ids []string
+			for _, f := range b.Player.Team {
+				ids = append(ids, strconv.Itoa(f.ID))
+			}
+			return ids
+		}
+	case "help":
+		return commandNames()
+	}
+	return nil
+}
+
+func caughtIDs(conf *Config) []string {
+	ids := make([]string, 0, len(conf.Pokedex.Caught))
+	for _, c := range conf.Pokedex.Caught {
+		ids = append(ids, strconv.Itoa(c.ID))
+	}
+	return ids
+}
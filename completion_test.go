@@ -0,0 +1,34 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/anegri01f01/pokegocli/internal/pokeapi"
+	"github.com/anegri01f01/pokegocli/internal/pokedex"
+)
+
+func TestCompleteLine(t *testing.T) {
+	conf := &Config{Pokedex: pokedex.New(), LastAreas: []string{"canalave-city-area", "kanto-route-1-area"}}
+	conf.Pokedex.Add(pokedex.CaughtPokemon{Name: "pikachu", Level: 5}, pokeapi.Pokemon{Name: "pikachu"})
+	complete := completeLine(conf)
+
+	cases := []struct {
+		line      string
+		wantStart int
+		want      []string
+	}{
+		{"ev", 0, []string{"evolution", "evolve"}},
+		{"explore ka", 8, []string{"kanto-route-1-area"}},
+		{"inspect ", 8, []string{"1", "pikachu"}},
+		{"moves pikachu --m", 14, []string{"--method"}},
+		{"release 2", 8, nil},
+		{"xyz", 0, nil},
+	}
+	for _, c := range cases {
+		start, got := complete(c.line, len(c.line))
+		if start != c.wantStart || !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%q: got %d %v, want %d %v", c.line, start, got, c.wantStart, c.want)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/anegri01f01/pokegocli/internal/encounter"
+	"github.com/anegri01f01/pokegocli/internal/pokeapi"
+)
+
+// chooseVersionAndMethod picks the game version and encounter method for
+// an area: the --version and --method options if given, else the ones
+// used before if the area has them, else the area's first version and
+// walking or its first method.
+func chooseVersionAndMethod(config *Config, area pokeapi.LocationArea) (string, string, error) {
+	versions := encounter.Versions(area)
+	if len(versions) == 0 {
+		return "", "", errors.New("no wild pokemon live in " + area.Name)
+	}
+	version, ok := config.Flags["version"]
+	switch {
+	case ok && !slices.Contains(versions, version):
+		return "", "", errors.New(area.Name + " has no encounters in " + version + " (try " + strings.Join(versions, ", ") + ")")
+	case !ok && slices.Contains(versions, config.Pokedex.Session.Version):
+		version = config.Pokedex.Session.Version
+	case !ok:
+		version = versions[0]
+	}
+
+	methods := encounter.Methods(area, version)
+	method, ok := config.Flags["method"]
+	switch {
+	case ok && !slices.Contains(methods, method):
+		return "", "", errors.New(area.Name + " has no " + method + " encounters in " + version + " (try " + strings.Join(methods, ", ") + ")")
+	case !ok && slices.Contains(methods, config.Pokedex.Session.Method):
+		method = config.Pokedex.Session.Method
+	case !ok && slices.Contains(methods, "walk"):
+		method = "walk"
+	case !ok:
+		method = methods[0]
+	}
+	return version, method, nil
+}
+
+// encounterTable lists the area's encounters in a version, grouped by
+// method in the order of the area's method rates and most likely first.
+// An empty method keeps every method.
+func encounterTable(area pokeapi.LocationArea, version, method string) []encounterSlot {
+	var methods []string
+	for _, rate := range encounter.MethodRates(area, version) {
+		methods = append(methods, rate.Method)
+	}
+	for _, m := range encounter.Methods(area, version) {
+		if !slices.Contains(methods, m) {
+			methods = append(methods, m)
+		}
+	}
+	slots := encounter.Slots(area, version, method)
+	slices.SortStableFunc(slots, func(a, b encounter.Slot) int {
+		if a.Method != b.Method {
+			return slices.Index(methods, a.Method) - slices.Index(methods, b.Method)
+		}
+		return b.Chance - a.Chance
+	})
+
+	table := make([]encounterSlot, 0, len(slots))
+	for _, slot := range slots {
+		table = append(table, encounterSlot{
+			Pokemon:  slot.Pokemon,
+			Method:   slot.Method,
+			Chance:   slot.Chance,
+			MinLevel: slot.MinLevel,
+			MaxLevel: slot.MaxLevel,
+		})
+	}
+	return table
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/anegri01f01/pokegocli/internal/i18n"
+	"github.com/anegri01f01/pokegocli/internal/pokeapi"
+)
+
+var resourceLabels = map[string]string{
+	"pokemon":         "Pokemon",
+	"location-area":   "location area",
+	"pokemon-species": "Pokemon species",
+}
+
+// describeError turns a command error into a message for the user.
+func describeError(config *Config, err error) string {
+	lang := language(config)
+	if errors.Is(err, context.Canceled) {
+		return i18n.T(lang, "Cancelled")
+	}
+
+	var usageErr *usageError
+	if errors.As(err, &usageErr) {
+		return usageErr.msg + "\n" + i18n.Sprintf(lang, "usage: %s", usageErr.cmd.usage())
+	}
+
+	var apiErr *pokeapi.Error
+	if !errors.As(err, &apiErr) {
+		return err.Error()
+	}
+
+	label, ok := resourceLabels[apiErr.Resource]
+	if !ok {
+		label = apiErr.Resource
+	}
+	label = i18n.T(lang, label)
+
+	switch apiErr.Kind {
+	case pokeapi.KindNotFound:
+		if apiErr.Name == "" {
+			return i18n.Sprintf(lang, "PokeAPI has no %s listing", label)
+		}
+		if suggestion := suggest(config, apiErr.Resource, apiErr.Name); suggestion != "" {
+			return i18n.Sprintf(lang, "no %s named '%s' — did you mean '%s'?", label, apiErr.Name, suggestion)
+		}
+		return i18n.Sprintf(lang, "no %s named '%s'", label, apiErr.Name)
+	case pokeapi.KindRateLimited:
+		return i18n.T(lang, "PokeAPI is rate limiting us, wait a moment and try again")
+	case pokeapi.KindServer:
+		return i18n.Sprintf(lang, "PokeAPI is having trouble (%d %s), try again later", apiErr.StatusCode, http.StatusText(apiErr.StatusCode))
+	case pokeapi.KindStatus:
+		return i18n.Sprintf(lang, "PokeAPI rejected the request for %s (%d %s)", apiErr.URL, apiErr.StatusCode, http.StatusText(apiErr.StatusCode))
+	case pokeapi.KindDecode:
+		return i18n.Sprintf(lang, "could not understand PokeAPI's answer for %s: %v", apiErr.URL, apiErr.Err)
+	case pokeapi.KindNetwork:
+		if errors.Is(apiErr.Err, context.DeadlineExceeded) {
+			return i18n.T(lang, "PokeAPI did not answer in time, try again or raise --timeout")
+		}
+		return i18n.Sprintf(lang, "could not reach PokeAPI: %v (use --offline to work from the cache)", apiErr.Err)
+	}
+	return apiErr.Error()
+}
+
+// suggest returns the known name closest to name, or "" if none is close.
+func suggest(config *Config, resource, name string) string {
+	var candidates []pokeapi.NamedResource
+	var err error
+	switch resource {
+	case "pokemon":
+		candidates, err = config.Client.ListAllPokemon(context.Background())
+	case "location-area":
+		candidates, err = config.Client.ListAllLocationAreas(context.Background())
+	default:
+		return ""
+	}
+	if err != nil {
+		return ""
+	}
+
+	best, bestDist := "", len(name)/3+2
+	for _, c := range candidates {
+		if d := levenshtein(name, c.Name); d < bestDist {
+			best, bestDist = c.Name, d
+		}
+	}
+	return best
+}
+
+func levenshtein(a, b string) int {
+	a, b = strings.ToLower(a), strings.ToLower(b)
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/anegri01f01/pokegocli/internal/battle"
+	"github.com/anegri01f01/pokegocli/internal/evolution"
+	"github.com/anegri01f01/pokegocli/internal/i18n"
+	"github.com/anegri01f01/pokegocli/internal/pokeapi"
+	"github.com/anegri01f01/pokegocli/internal/pokedex"
+)
+
+func commandEvolution(ctx context.Context, config *Config) (any, error) {
+	var species string
+	if _, err := parseID(config.Args[0]); err == nil {
+		caught, err := caughtByID(config, config.Args[0])
+		if err != nil {
+			return nil, err
+		}
+		species = config.Pokedex.Pokemon[caught.Name].Species.Name
+	} else {
+		name := strings.ToLower(config.Args[0])
+		data, ok := config.Pokedex.Pokemon[name]
+		if !ok {
+			if data, err = config.Client.GetPokemon(ctx, name); err != nil {
+				return nil, err
+			}
+		}
+		species = data.Species.Name
+	}
+
+	chain, err := config.Client.GetSpeciesEvolutionChain(ctx, species)
+	if err != nil {
+		return nil, err
+	}
+	return evolutionResult{Species: species, Chain: newEvolutionStage(evolution.Tree(language(config), chain.Chain)), lang: language(config)}, nil
+}
+
+func newEvolutionStage(n evolution.Node) evolutionStage {
+	stage := evolutionStage{
+		Species:    n.Species,
+		Baby:       n.IsBaby,
+		Conditions: append([]string{}, n.Conditions...),
+		EvolvesTo:  []evolutionStage{},
+	}
+	for _, next := range n.EvolvesTo {
+		stage.EvolvesTo = append(stage.EvolvesTo, newEvolutionStage(next))
+	}
+	return stage
+}
+
+func commandEvolve(ctx context.Context, config *Config) (any, error) {
+	if err := notInBattle(config); err != nil {
+		return nil, err
+	}
+	caught, err := caughtByID(config, config.Args[0])
+	if err != nil {
+		return nil, err
+	}
+	data := config.Pokedex.Pokemon[caught.Name]
+	chain, err := config.Client.GetSpeciesEvolutionChain(ctx, data.Species.Name)
+	if err != nil {
+		return nil, err
+	}
+	next := evolution.Next(chain, data.Species.Name)
+	if len(next) == 0 {
+		return nil, errors.New(caught.DisplayName() + " does not evolve")
+	}
+	if into, ok := config.Flags["into"]; ok {
+		into = strings.ToLower(into)
+		var names []string
+		for _, link := range next {
+			names = append(names, link.Species.Name)
+		}
+		i := slices.Index(names, into)
+		if i < 0 {
+			return nil, &usageError{cmdRegistry["evolve"], i18n.Sprintf(language(config), "%s does not evolve into %s, only %s", caught.Name, into, strings.Join(names, ", "))}
+		}
+		next = next[i : i+1]
+	}
+
+	subject, err := evolutionSubject(ctx, config, caught, data, next)
+	if err != nil {
+		return nil, err
+	}
+	var reasons []string
+	for _, link := range next {
+		var unmet []string
+		for _, detail := range link.EvolutionDetails {
+			err := evolution.Check(detail, subject)
+			if err == nil {
+				return evolve(ctx, config, caught, link.Species.Name, detail)
+			}
+			unmet = append(unmet, err.Error())
+		}
+		reasons = append(reasons, "into "+link.Species.Name+" "+strings.Join(unmet, ", or "))
+	}
+	return nil, errors.New(caught.DisplayName() + " cannot evolve yet: " + strings.Join(reasons, "; "))
+}
+
+// evolutionSubject gathers what the conditions of the next stages ask
+// about a caught Pokemon.
+func evolutionSubject(ctx context.Context, config *Config, caught *pokedex.CaughtPokemon, data pokeapi.Pokemon, next []pokeapi.ChainLink) (evolution.Subject, error) {
+	subject := evolution.Subject{
+		Level:      caught.Level,
+		Friendship: caught.Friendship,
+		Gender:     caught.Gender,
+		Moves:      battle.LevelUpMoves(data, caught.Level),
+		Items:      config.Pokedex.Inventory,
+		TimeOfDay:  "day",
+	}
+	if isNight(time.Now()) {
+		subject.TimeOfDay = "night"
+	}
+	for _, link := range next {
+		for _, detail := range link.EvolutionDetails {
+			if detail.KnownMoveType == nil || subject.MoveTypes != nil {
+				continue
+			}
+			subject.MoveTypes = []string{}
+			for _, name := range subject.Moves {
+				move, err := config.Client.GetMove(ctx, name)
+				if err != nil {
+					return subject, err
+				}
+				subject.MoveTypes = append(subject.MoveTypes, move.Type.Name)
+			}
+		}
+	}
+	return subject, nil
+}
+
+// evolve turns a caught Pokemon into the species it evolves into, using up
+// the item the evolution takes.
+func evolve(ctx context.Context, config *Config, caught *pokedex.CaughtPokemon, species string, detail pokeapi.EvolutionDetail) (any, error) {
+	data, err := config.Client.GetPokemon(ctx, species)
+	if err != nil {
+		return nil, err
+	}
+	result := evolveResult{ID: caught.ID, From: caught.Name, Into: data.Name, Nickname: caught.Nickname, lang: language(config)}
+	if detail.Trigger.Name == "use-item" && detail.Item != nil {
+		config.Pokedex.UseItem(detail.Item.Name)
+		result.Item = detail.Item.Name
+	}
+	caught.Ability = evolvedAbility(config.Pokedex.Pokemon[caught.Name], data, caught.Ability)
+	caught.Name = data.Name
+	config.Pokedex.Pokemon[data.Name] = data
+	if err := config.Pokedex.Save(config.PokedexPath); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// evolvedAbility is the ability of the evolved species in the slot the
+// ability had before, or the old ability when there is no such slot.
+func evolvedAbility(from, into pokeapi.Pokemon, ability string) string {
+	slot := 0
+	for _, a := range from.Abilities {
+		if a.Ability.Name == ability {
+			slot = a.Slot
+		}
+	}
+	for _, a := range into.Abilities {
+		if a.Slot == slot {
+			return a.Ability.Name
+		}
+	}
+	return ability
+}
@@ -0,0 +1,380 @@
+// Package battle simulates turn-based battles between teams of Pokemon:
+// a trainer's team against a wild Pokemon or another trainer's team.
+// Damage follows the formula of the fifth generation games onwards, with
+// STAB, type effectiveness, critical hits, accuracy and stat stages.
+// Status conditions, abilities and held items are not simulated.
+package battle
+
+import (
+	"math/rand"
+	"strings"
+
+	"github.com/anegri01f01/pokegocli/internal/i18n"
+	"github.com/anegri01f01/pokegocli/internal/pokeapi"
+	"github.com/anegri01f01/pokegocli/internal/stats"
+)
+
+// Kinds of battle.
+const (
+	Wild    = "wild"
+	Trainer = "trainer"
+)
+
+// Outcomes of a finished battle.
+const (
+	Won  = "won"
+	Lost = "lost"
+	Fled = "fled"
+)
+
+// Fighter is a Pokemon taking part in a battle.
+type Fighter struct {
+	// ID is the caught Pokemon's ID, 0 for an opponent.
+	ID       int         `json:"id,omitempty"`
+	Name     string      `json:"name"`
+	Nickname string      `json:"nickname,omitempty"`
+	Level    int         `json:"level"`
+	Types    []string    `json:"types"`
+	Stats    stats.Stats `json:"stats"`
+	HP       int         `json:"hp"`
+	Moves    []Move      `json:"moves"`
+	// Stages are the stat stages from -MaxStage to MaxStage by stat name,
+	// accuracy and evasion included. They reset when it is switched out.
+	Stages map[string]int `json:"stages,omitempty"`
+}
+
+// NewFighter prepares a Pokemon for battle with full HP.
+func NewFighter(data pokeapi.Pokemon, level int, ivs stats.Stats, nature stats.Nature, moves []Move) *Fighter {
+	f := &Fighter{
+		Name:  data.Name,
+		Level: level,
+		Stats: stats.Compute(stats.Base(data), ivs, level, nature),
+		Moves: moves,
+	}
+	for _, t := range data.Types {
+		f.Types = append(f.Types, t.Type.Name)
+	}
+	f.HP = f.Stats.HP
+	return f
+}
+
+// DisplayName is the nickname, or the Pokemon's name without one.
+func (f *Fighter) DisplayName() string {
+	if f.Nickname != "" {
+		return f.Nickname
+	}
+	return f.Name
+}
+
+// Fainted reports whether the Pokemon has no HP left.
+func (f *Fighter) Fainted() bool {
+	return f.HP <= 0
+}
+
+// MoveIndex finds one of the Pokemon's moves by name.
+func (f *Fighter) MoveIndex(name string) (int, bool) {
+	for i, m := range f.Moves {
+		if m.Name == strings.ToLower(name) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// Side is one trainer's team, or a lone wild Pokemon.
+type Side struct {
+	Team []*Fighter `json:"team"`
+	// Active is the index in Team of the Pokemon in battle.
+	Active int `json:"active"`
+}
+
+// Fighter returns the Pokemon in battle.
+func (s *Side) Fighter() *Fighter {
+	return s.Team[s.Active]
+}
+
+// next returns the index of the first Pokemon able to battle, or -1.
+func (s *Side) next() int {
+	for i, f := range s.Team {
+		if !f.Fainted() {
+			return i
+		}
+	}
+	return -1
+}
+
+// Battle is a battle in progress. It is plain data so that it can be
+// saved between one-shot commands.
+type Battle struct {
+	Kind string `json:"kind"`
+	// Trainer is the opponent's name in a trainer battle.
+	Trainer  string `json:"trainer,omitempty"`
+	Player   Side   `json:"player"`
+	Opponent Side   `json:"opponent"`
+	Turns    int    `json:"turns"`
+	// Outcome is empty until the battle is over.
+	Outcome string `json:"outcome,omitempty"`
+}
+
+// Start returns the messages that open the battle, in lang.
+func (b *Battle) Start(lang string) []string {
+	foe := b.Opponent.Fighter()
+	var log []string
+	if b.Kind == Wild {
+		log = append(log, i18n.Sprintf(lang, "A wild %s (Lv. %d) wants to battle!", foe.Name, foe.Level))
+	} else {
+		trainer := i18n.T(lang, b.Trainer)
+		log = append(log, i18n.Sprintf(lang, "%s wants to battle!", trainer))
+		log = append(log, i18n.Sprintf(lang, "%s sent out %s (Lv. %d)!", trainer, foe.Name, foe.Level))
+	}
+	return append(log, i18n.Sprintf(lang, "Go! %s!", b.Player.Fighter().DisplayName()))
+}
+
+// ActionKind is what the player does on a turn.
+type ActionKind int
+
+const (
+	// Pass does nothing, e.g. while a ball is thrown.
+	Pass ActionKind = iota
+	Fight
+	Switch
+	Flee
+)
+
+// Action is the player's choice for a turn.
+type Action struct {
+	Kind ActionKind
+	// Index is the move to use for Fight and the team member to send out
+	// for Switch.
+	Index int
+}
+
+// Turn plays one turn: the player's action and the opponent's move, and
+// returns what happened in lang. Switching and fleeing come before any move;
+// moves go by priority, then speed. The player's fainted Pokemon is
+// replaced by the next one in the team.
+func (b *Battle) Turn(lang string, rng *rand.Rand, eff Effectiveness, action Action) []string {
+	if b.Outcome != "" {
+		return nil
+	}
+	t := &turn{b: b, lang: lang, rng: rng, eff: eff}
+	b.Turns++
+
+	switch action.Kind {
+	case Flee:
+		b.Outcome = Fled
+		return []string{i18n.T(lang, "Got away safely!")}
+	case Switch:
+		t.logf("Come back, %s! Go! %s!", b.Player.Fighter().DisplayName(), b.Player.Team[action.Index].DisplayName())
+		b.Player.Fighter().Stages = nil
+		b.Player.Active = action.Index
+	}
+
+	player, foe := b.Player.Fighter(), b.Opponent.Fighter()
+	foeMove := foe.Moves[b.chooseMove(rng, eff, foe, player, b.Kind == Wild)]
+	if action.Kind != Fight {
+		t.use(foe, player, foeMove)
+	} else {
+		playerMove := player.Moves[action.Index]
+		if t.first(player, foe, playerMove, foeMove) {
+			t.use(player, foe, playerMove)
+			t.use(foe, player, foeMove)
+		} else {
+			t.use(foe, player, foeMove)
+			t.use(player, foe, playerMove)
+		}
+	}
+	t.replaceFainted()
+	return t.log
+}
+
+// Auto plays the player's side as a trainer would, until the battle is
+// over or maxTurns have been played.
+func (b *Battle) Auto(lang string, rng *rand.Rand, eff Effectiveness, maxTurns int) []string {
+	var log []string
+	for i := 0; i < maxTurns && b.Outcome == ""; i++ {
+		move := b.chooseMove(rng, eff, b.Player.Fighter(), b.Opponent.Fighter(), false)
+		log = append(log, b.Turn(lang, rng, eff, Action{Kind: Fight, Index: move})...)
+	}
+	return log
+}
+
+// chooseMove picks a move: wild Pokemon pick at random, trainers the move
+// expected to do the most damage, or a random one when none does damage.
+func (b *Battle) chooseMove(rng *rand.Rand, eff Effectiveness, user, target *Fighter, random bool) int {
+	if !random {
+		best, bestDamage := -1, 0.0
+		for i, m := range user.Moves {
+			if d := expectedDamage(user, target, m, eff); d > bestDamage {
+				best, bestDamage = i, d
+			}
+		}
+		if best >= 0 {
+			return best
+		}
+	}
+	return rng.Intn(len(user.Moves))
+}
+
+type turn struct {
+	b    *Battle
+	lang string
+	rng  *rand.Rand
+	eff  Effectiveness
+	log  []string
+}
+
+func (t *turn) logf(format string, args ...any) {
+	t.log = append(t.log, i18n.Sprintf(t.lang, format, args...))
+}
+
+// name is how a Pokemon is called in the messages.
+func (t *turn) name(f *Fighter) string {
+	if f != t.b.Opponent.Fighter() {
+		return f.DisplayName()
+	}
+	if t.b.Kind == Wild {
+		return i18n.Sprintf(t.lang, "the wild %s", f.Name)
+	}
+	return i18n.Sprintf(t.lang, "the foe's %s", f.Name)
+}
+
+// subject is how a Pokemon is called at the start of a message.
+func (t *turn) subject(f *Fighter) string {
+	if f != t.b.Opponent.Fighter() {
+		return f.DisplayName()
+	}
+	if t.b.Kind == Wild {
+		return i18n.Sprintf(t.lang, "The wild %s", f.Name)
+	}
+	return i18n.Sprintf(t.lang, "The foe's %s", f.Name)
+}
+
+// first reports whether the player moves before the opponent.
+func (t *turn) first(player, foe *Fighter, playerMove, foeMove Move) bool {
+	if playerMove.Priority != foeMove.Priority {
+		return playerMove.Priority > foeMove.Priority
+	}
+	ps := staged(player.Stats.Speed, player.Stages["speed"])
+	fs := staged(foe.Stats.Speed, foe.Stages["speed"])
+	if ps != fs {
+		return ps > fs
+	}
+	return t.rng.Intn(2) == 0
+}
+
+// use plays one move.
+func (t *turn) use(user, target *Fighter, m Move) {
+	if user.Fainted() || target.Fainted() {
+		return
+	}
+	t.logf("%s used %s!", t.subject(user), m.Name)
+	if float64(t.rng.Intn(100)) >= hitChance(m, user, target) {
+		t.logf("%s's attack missed!", t.subject(user))
+		return
+	}
+
+	if m.Class == Status {
+		affected := target
+		if m.selfTargeted() {
+			affected = user
+		}
+		if len(m.StatChanges) == 0 {
+			t.logf("But nothing happened!")
+		}
+		t.changeStats(affected, m.StatChanges)
+		return
+	}
+
+	if _, ok := m.fixedDamage(user); !ok && m.power(user) == 0 {
+		t.logf("But it failed!")
+		return
+	}
+	eff := t.eff(m.Type, target.Types)
+	if eff == 0 {
+		t.logf("It doesn't affect %s...", t.name(target))
+		return
+	}
+	crit := critical(t.rng, m)
+	damage := min(Damage(user, target, m, eff, crit, 85+t.rng.Intn(16)), target.HP)
+	target.HP -= damage
+	if crit {
+		t.logf("A critical hit!")
+	}
+	switch {
+	case eff > 1:
+		t.logf("It's super effective!")
+	case eff < 1:
+		t.logf("It's not very effective...")
+	}
+	t.logf("%s lost %d HP.", t.subject(target), damage)
+	if target.Fainted() {
+		t.logf("%s fainted!", t.subject(target))
+		return
+	}
+	if len(m.StatChanges) > 0 && t.rng.Intn(100) < m.EffectChance {
+		affected := target
+		if m.selfTargeted() {
+			affected = user
+		}
+		t.changeStats(affected, m.StatChanges)
+	}
+}
+
+// changeStats raises or lowers stat stages.
+func (t *turn) changeStats(f *Fighter, changes []StatChange) {
+	for _, sc := range changes {
+		stage := f.Stages[sc.Stat]
+		changed := min(max(stage+sc.Change, -MaxStage), MaxStage)
+		name := i18n.Sprintf(t.lang, "%s's %s", t.subject(f), sc.Stat)
+		switch {
+		case changed == stage && sc.Change < 0:
+			t.logf("%s won't go any lower!", name)
+			continue
+		case changed == stage:
+			t.logf("%s won't go any higher!", name)
+			continue
+		case changed-stage <= -2:
+			t.logf("%s harshly fell!", name)
+		case changed-stage < 0:
+			t.logf("%s fell!", name)
+		case changed-stage >= 2:
+			t.logf("%s sharply rose!", name)
+		default:
+			t.logf("%s rose!", name)
+		}
+		if f.Stages == nil {
+			f.Stages = make(map[string]int)
+		}
+		f.Stages[sc.Stat] = changed
+	}
+}
+
+// replaceFainted sends out the next Pokemon of a side whose Pokemon
+// fainted, and ends the battle when a side has none left.
+func (t *turn) replaceFainted() {
+	b := t.b
+	if b.Opponent.Fighter().Fainted() {
+		next := b.Opponent.next()
+		if next < 0 {
+			b.Outcome = Won
+			if b.Kind == Trainer {
+				t.logf("You defeated %s!", i18n.T(t.lang, b.Trainer))
+			}
+			return
+		}
+		b.Opponent.Active = next
+		foe := b.Opponent.Fighter()
+		t.logf("%s sent out %s (Lv. %d)!", i18n.T(t.lang, b.Trainer), foe.Name, foe.Level)
+	}
+	if b.Player.Fighter().Fainted() {
+		next := b.Player.next()
+		if next < 0 {
+			b.Outcome = Lost
+			t.logf("You have no more pokemon that can fight! You blacked out!")
+			return
+		}
+		b.Player.Active = next
+		t.logf("Go! %s!", b.Player.Fighter().DisplayName())
+	}
+}
@@ -0,0 +1,190 @@
+package battle
+
+import (
+	"context"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/anegri01f01/pokegocli/internal/fixtures"
+	"github.com/anegri01f01/pokegocli/internal/i18n"
+	"github.com/anegri01f01/pokegocli/internal/pokeapi"
+	"github.com/anegri01f01/pokegocli/internal/stats"
+)
+
+var (
+	thunderbolt = Move{Name: "thunderbolt", Type: "electric", Class: Special, Power: 90, Accuracy: 100, Target: "selected-pokemon"}
+	tackle      = Move{Name: "tackle", Type: "normal", Class: Physical, Power: 40, Accuracy: 100, Target: "selected-pokemon"}
+	growl       = Move{Name: "growl", Type: "normal", Class: Status, Accuracy: 100, Target: "all-opponents", StatChanges: []StatChange{{"attack", -1}}}
+	splash      = Move{Name: "splash", Type: "normal", Class: Status, Target: "user"}
+	dragonRage  = Move{Name: "dragon-rage", Type: "dragon", Class: Special, Accuracy: 100, Target: "selected-pokemon"}
+)
+
+// neutral treats every type as normally effective, except electric
+// against water.
+func neutral(attack string, defend []string) float64 {
+	if attack == "electric" && defend[0] == "water" {
+		return 2
+	}
+	return 1
+}
+
+func TestDamage(t *testing.T) {
+	pikachu := &Fighter{Name: "pikachu", Level: 10, Types: []string{"electric"}, Stats: stats.Stats{HP: 30, SpecialAttack: 20, Attack: 20}}
+	magikarp := &Fighter{Name: "magikarp", Level: 10, Types: []string{"water"}, Stats: stats.Stats{HP: 28, SpecialDefense: 15, Defense: 30}}
+
+	// floor(floor(6 * 90 * 20 / 15) / 50) + 2 = 16, then STAB and x2.
+	if got := Damage(pikachu, magikarp, thunderbolt, 2, false, 100); got != 48 {
+		t.Errorf("super effective damage = %d, want 48", got)
+	}
+	// 16 x1.5 critical = 24, x0.85 roll = 20, STAB = 30, halved = 15.
+	if got := Damage(pikachu, magikarp, thunderbolt, 0.5, true, 85); got != 15 {
+		t.Errorf("critical damage = %d, want 15", got)
+	}
+
+	pikachu.Stages = map[string]int{"special-attack": -1}
+	if got := Damage(pikachu, magikarp, thunderbolt, 1, false, 100); got != 16 {
+		t.Errorf("damage after a stat drop = %d, want 16", got)
+	}
+	if got := Damage(pikachu, magikarp, thunderbolt, 1, true, 100); got != 36 {
+		t.Errorf("critical hits should ignore the drop, got %d, want 36", got)
+	}
+
+	if got := Damage(pikachu, magikarp, dragonRage, 1, false, 85); got != 40 {
+		t.Errorf("dragon-rage = %d, want 40", got)
+	}
+	if got := Damage(pikachu, magikarp, tackle, 0, false, 100); got != 0 {
+		t.Errorf("damage to an immune type = %d, want 0", got)
+	}
+}
+
+func TestHitChance(t *testing.T) {
+	user := &Fighter{Stages: map[string]int{"accuracy": -1}}
+	target := &Fighter{}
+	if got := hitChance(tackle, user, target); got != 75 {
+		t.Errorf("hit chance at accuracy -1 = %v, want 75", got)
+	}
+	target.Stages = map[string]int{"evasion": -7}
+	if got := hitChance(tackle, user, target); got != 300 {
+		t.Errorf("hit chance at stage +6 = %v, want 300", got)
+	}
+	if got := hitChance(splash, user, target); got != 100 {
+		t.Errorf("moves on the user always hit, got %v", got)
+	}
+}
+
+func newTestBattle() *Battle {
+	pikachu := &Fighter{ID: 1, Name: "pikachu", Nickname: "Sparky", Level: 10, Types: []string{"electric"},
+		Stats: stats.Stats{HP: 30, Attack: 15, Defense: 12, SpecialAttack: 14, SpecialDefense: 14, Speed: 22},
+		Moves: []Move{growl, thunderbolt}}
+	pikachu.HP = pikachu.Stats.HP
+	magikarp := &Fighter{Name: "magikarp", Level: 10, Types: []string{"water"},
+		Stats: stats.Stats{HP: 28, Attack: 8, Defense: 14, SpecialAttack: 7, SpecialDefense: 10, Speed: 20},
+		Moves: []Move{splash}}
+	magikarp.HP = magikarp.Stats.HP
+	return &Battle{
+		Kind:     Wild,
+		Player:   Side{Team: []*Fighter{pikachu}},
+		Opponent: Side{Team: []*Fighter{magikarp}},
+	}
+}
+
+func TestTurn(t *testing.T) {
+	b := newTestBattle()
+	rng := rand.New(rand.NewSource(1))
+
+	log := b.Turn(i18n.English, rng, neutral, Action{Kind: Fight, Index: 0})
+	if log[0] != "Sparky used growl!" || log[1] != "The wild magikarp's attack fell!" {
+		t.Errorf("faster pokemon should move first:\n%s", strings.Join(log, "\n"))
+	}
+	if got := b.Opponent.Fighter().Stages["attack"]; got != -1 {
+		t.Errorf("attack stage = %d, want -1", got)
+	}
+	if !strings.Contains(strings.Join(log, "\n"), "But nothing happened!") {
+		t.Errorf("splash should do nothing:\n%s", strings.Join(log, "\n"))
+	}
+
+	for b.Outcome == "" && b.Turns < 10 {
+		log = b.Turn(i18n.English, rng, neutral, Action{Kind: Fight, Index: 1})
+	}
+	if b.Outcome != Won {
+		t.Fatalf("outcome = %q after %d turns", b.Outcome, b.Turns)
+	}
+	if got := log[len(log)-1]; got != "The wild magikarp fainted!" {
+		t.Errorf("last message = %q", got)
+	}
+	if b.Turn(i18n.English, rng, neutral, Action{Kind: Fight}) != nil {
+		t.Error("a finished battle should not play more turns")
+	}
+}
+
+func TestTurnInLanguage(t *testing.T) {
+	b := newTestBattle()
+	log := b.Turn("ja", rand.New(rand.NewSource(1)), neutral, Action{Kind: Fight, Index: 0})
+	if log[0] != "Sparky の growl!" || log[1] != "やせいの magikarp の attack が さがった!" {
+		t.Errorf("unexpected narration:\n%s", strings.Join(log, "\n"))
+	}
+}
+
+func TestTrainerBattle(t *testing.T) {
+	b := newTestBattle()
+	b.Kind, b.Trainer = Trainer, "Fisherman"
+	second := *b.Opponent.Team[0]
+	b.Opponent.Team = append(b.Opponent.Team, &second)
+	rng := rand.New(rand.NewSource(2))
+
+	log := b.Auto(i18n.English, rng, neutral, 20)
+	text := strings.Join(log, "\n")
+	if b.Outcome != Won {
+		t.Fatalf("outcome = %q:\n%s", b.Outcome, text)
+	}
+	for _, want := range []string{"Sparky used thunderbolt!", "It's super effective!", "Fisherman sent out magikarp (Lv. 10)!", "You defeated Fisherman!"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("log missing %q:\n%s", want, text)
+		}
+	}
+	if strings.Contains(text, "used growl") {
+		t.Errorf("a trainer should prefer damaging moves:\n%s", text)
+	}
+}
+
+func TestSwitchAndFlee(t *testing.T) {
+	b := newTestBattle()
+	raichu := &Fighter{ID: 2, Name: "raichu", Level: 10, Types: []string{"electric"}, Stats: stats.Stats{HP: 35, Speed: 30}, HP: 35, Moves: []Move{tackle}}
+	b.Player.Team = append(b.Player.Team, raichu)
+	b.Player.Fighter().Stages = map[string]int{"speed": 2}
+	rng := rand.New(rand.NewSource(1))
+
+	log := b.Turn(i18n.English, rng, neutral, Action{Kind: Switch, Index: 1})
+	if log[0] != "Come back, Sparky! Go! raichu!" || b.Player.Fighter() != raichu {
+		t.Errorf("switch failed:\n%s", strings.Join(log, "\n"))
+	}
+	if b.Player.Team[0].Stages != nil {
+		t.Error("stat stages should reset when switched out")
+	}
+
+	if log := b.Turn(i18n.English, rng, neutral, Action{Kind: Flee}); b.Outcome != Fled || log[0] != "Got away safely!" {
+		t.Errorf("flee: outcome %q, log %q", b.Outcome, log)
+	}
+}
+
+func TestLevelUpMoves(t *testing.T) {
+	srv := fixtures.NewServer()
+	t.Cleanup(srv.Close)
+	client := pokeapi.NewClient(pokeapi.WithBaseURL(srv.BaseURL()))
+	pikachu, err := client.GetPokemon(context.Background(), "pikachu")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[int][]string{
+		1:  {"thunder-shock", "growl"},
+		30: {"growl", "tail-whip", "quick-attack", "thunderbolt"},
+	}
+	for level, want := range cases {
+		if got := LevelUpMoves(pikachu, level); !reflect.DeepEqual(got, want) {
+			t.Errorf("LevelUpMoves(%d) = %q, want %q", level, got, want)
+		}
+	}
+}
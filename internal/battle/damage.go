@@ -0,0 +1,91 @@
+package battle
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+)
+
+// MaxStage is how far a stat can be raised or lowered.
+const MaxStage = 6
+
+// STAB is the same-type attack bonus.
+const STAB = 1.5
+
+// CritMultiplier is the damage bonus of a critical hit.
+const CritMultiplier = 1.5
+
+// critOdds is the one-in-n chance of a critical hit by stage.
+var critOdds = []int{24, 8, 2, 1}
+
+// Effectiveness returns the multiplier of an attacking type against a
+// Pokemon with the given types: 0, 0.25, 0.5, 1, 2 or 4.
+type Effectiveness func(attack string, defend []string) float64
+
+// Damage works out the damage a move does, following the formula of the
+// fifth generation games onwards. roll is the random factor from 85 to
+// 100. A critical hit ignores the user's lowered and the target's raised
+// stat stages.
+func Damage(user, target *Fighter, m Move, effectiveness float64, crit bool, roll int) int {
+	if effectiveness == 0 {
+		return 0
+	}
+	if fixed, ok := m.fixedDamage(user); ok {
+		return fixed
+	}
+	atk, def := "attack", "defense"
+	if m.Class == Special {
+		atk, def = "special-attack", "special-defense"
+	}
+	atkStage, defStage := user.Stages[atk], target.Stages[def]
+	if crit {
+		atkStage, defStage = max(atkStage, 0), min(defStage, 0)
+	}
+	a := staged(user.Stats.Get(atk), atkStage)
+	d := staged(target.Stats.Get(def), defStage)
+
+	damage := float64((2*user.Level/5+2)*m.power(user)*a/d/50 + 2)
+	if crit {
+		damage = math.Floor(damage * CritMultiplier)
+	}
+	damage = math.Floor(damage * float64(roll) / 100)
+	if slices.Contains(user.Types, m.Type) {
+		damage = math.Floor(damage * STAB)
+	}
+	damage = math.Floor(damage * effectiveness)
+	return max(int(damage), 1)
+}
+
+// staged applies a stat stage to a stat.
+func staged(stat, stage int) int {
+	return stat * max(2, 2+stage) / max(2, 2-stage)
+}
+
+// hitChance is the percentage chance of a move hitting, with the user's
+// accuracy and the target's evasion stages.
+func hitChance(m Move, user, target *Fighter) float64 {
+	if m.Accuracy == 0 || m.selfTargeted() {
+		return 100
+	}
+	stage := min(max(user.Stages["accuracy"]-target.Stages["evasion"], -MaxStage), MaxStage)
+	return float64(m.Accuracy) * float64(max(3, 3+stage)) / float64(max(3, 3-stage))
+}
+
+// critical rolls for a critical hit.
+func critical(rng *rand.Rand, m Move) bool {
+	stage := min(max(m.CritStage, 0), len(critOdds)-1)
+	return rng.Intn(critOdds[stage]) == 0
+}
+
+// expectedDamage estimates a move's damage for choosing between moves:
+// an average roll, no critical hit, weighed by the chance to hit.
+func expectedDamage(user, target *Fighter, m Move, eff Effectiveness) float64 {
+	if m.Class == Status {
+		return 0
+	}
+	if _, ok := m.fixedDamage(user); !ok && m.power(user) == 0 {
+		return 0
+	}
+	damage := Damage(user, target, m, eff(m.Type, target.Types), false, 92)
+	return float64(damage) * min(hitChance(m, user, target), 100) / 100
+}
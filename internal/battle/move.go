@@ -0,0 +1,148 @@
+package battle
+
+import (
+	"sort"
+
+	"github.com/anegri01f01/pokegocli/internal/pokeapi"
+)
+
+// Damage classes of a move.
+const (
+	Physical = "physical"
+	Special  = "special"
+	Status   = "status"
+)
+
+// MaxMoves is the number of moves a Pokemon knows at most.
+const MaxMoves = 4
+
+// Move is what a Pokemon can do on its turn.
+type Move struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Class string `json:"class"`
+	// Power is 0 for status moves and moves whose damage is worked out
+	// some other way.
+	Power int `json:"power,omitempty"`
+	// Accuracy is a percentage, 0 for moves that never miss.
+	Accuracy int `json:"accuracy,omitempty"`
+	Priority int `json:"priority,omitempty"`
+	// CritStage raises the move's critical hit stage.
+	CritStage int    `json:"crit_stage,omitempty"`
+	Target    string `json:"target"`
+	// StatChanges are the stat stages the move changes: always for status
+	// moves, with EffectChance percent for damaging ones.
+	StatChanges  []StatChange `json:"stat_changes,omitempty"`
+	EffectChance int          `json:"effect_chance,omitempty"`
+}
+
+// StatChange raises or lowers one stat by a number of stages.
+type StatChange struct {
+	Stat   string `json:"stat"`
+	Change int    `json:"change"`
+}
+
+// NewMove converts PokeAPI move data.
+func NewMove(m pokeapi.Move) Move {
+	move := Move{
+		Name:     m.Name,
+		Type:     m.Type.Name,
+		Class:    m.DamageClass.Name,
+		Priority: m.Priority,
+		Target:   m.Target.Name,
+	}
+	if m.Power != nil {
+		move.Power = *m.Power
+	}
+	if m.Accuracy != nil {
+		move.Accuracy = *m.Accuracy
+	}
+	if m.Meta != nil {
+		move.CritStage = m.Meta.CritRate
+	}
+	if m.EffectChance != nil {
+		move.EffectChance = *m.EffectChance
+	}
+	for _, sc := range m.StatChanges {
+		move.StatChanges = append(move.StatChanges, StatChange{sc.Stat.Name, sc.Change})
+	}
+	return move
+}
+
+// selfTargeted reports whether the move's stat changes apply to its user.
+func (m Move) selfTargeted() bool {
+	switch m.Target {
+	case "user", "users-field", "user-and-allies", "user-or-ally":
+		return true
+	}
+	return false
+}
+
+// fixedDamage returns the damage of moves that ignore the damage formula.
+func (m Move) fixedDamage(user *Fighter) (int, bool) {
+	switch m.Name {
+	case "dragon-rage":
+		return 40, true
+	case "sonic-boom":
+		return 20, true
+	case "seismic-toss", "night-shade":
+		return user.Level, true
+	}
+	return 0, false
+}
+
+// power returns the move's power for its user, working out the power of
+// moves like flail that grow stronger as the user's HP runs low.
+func (m Move) power(user *Fighter) int {
+	switch m.Name {
+	case "flail", "reversal":
+		switch p := 48 * user.HP / user.Stats.HP; {
+		case p >= 33:
+			return 20
+		case p >= 17:
+			return 40
+		case p >= 10:
+			return 80
+		case p >= 5:
+			return 100
+		case p >= 2:
+			return 150
+		}
+		return 200
+	}
+	return m.Power
+}
+
+// LevelUpMoves returns the names of the last MaxMoves moves a Pokemon
+// learned by leveling up to the given level, the way a wild Pokemon knows
+// them. Moves learned at the same level keep PokeAPI's order.
+func LevelUpMoves(p pokeapi.Pokemon, level int) []string {
+	type learned struct {
+		name  string
+		level int
+	}
+	var moves []learned
+	for _, m := range p.Moves {
+		at := -1
+		for _, vgd := range m.VersionGroupDetails {
+			if vgd.MoveLearnMethod.Name != "level-up" || vgd.LevelLearnedAt > level {
+				continue
+			}
+			if at < 0 || vgd.LevelLearnedAt < at {
+				at = vgd.LevelLearnedAt
+			}
+		}
+		if at >= 0 {
+			moves = append(moves, learned{m.Move.Name, at})
+		}
+	}
+	sort.SliceStable(moves, func(i, j int) bool { return moves[i].level < moves[j].level })
+	if len(moves) > MaxMoves {
+		moves = moves[len(moves)-MaxMoves:]
+	}
+	names := make([]string, len(moves))
+	for i, m := range moves {
+		names[i] = m.name
+	}
+	return names
+}
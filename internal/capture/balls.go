@@ -0,0 +1,155 @@
+package capture
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+)
+
+// Ball is a kind of ball, named like the PokeAPI item.
+type Ball string
+
+const (
+	PokeBall    Ball = "poke-ball"
+	GreatBall   Ball = "great-ball"
+	UltraBall   Ball = "ultra-ball"
+	MasterBall  Ball = "master-ball"
+	PremierBall Ball = "premier-ball"
+	LuxuryBall  Ball = "luxury-ball"
+	NetBall     Ball = "net-ball"
+	NestBall    Ball = "nest-ball"
+	RepeatBall  Ball = "repeat-ball"
+	TimerBall   Ball = "timer-ball"
+	QuickBall   Ball = "quick-ball"
+	DuskBall    Ball = "dusk-ball"
+	DiveBall    Ball = "dive-ball"
+)
+
+// Balls lists every ball, from the most common.
+var Balls = []Ball{
+	PokeBall, GreatBall, UltraBall, MasterBall, PremierBall, LuxuryBall,
+	NetBall, NestBall, RepeatBall, TimerBall, QuickBall, DuskBall, DiveBall,
+}
+
+var ballDescriptions = map[Ball]string{
+	PokeBall:    "a plain ball",
+	GreatBall:   "a good ball, x1.5",
+	UltraBall:   "a better ball, x2",
+	MasterBall:  "never fails",
+	PremierBall: "a commemorative Poke Ball",
+	LuxuryBall:  "a comfortable Poke Ball",
+	NetBall:     "x3 on Water and Bug types",
+	NestBall:    "better the lower the level, up to x3.9",
+	RepeatBall:  "x3 on species already caught",
+	TimerBall:   "better the more throws, up to x4",
+	QuickBall:   "x4 on the first throw",
+	DuskBall:    "x3.5 at night and in caves",
+	DiveBall:    "x3.5 when surfing or fishing",
+}
+
+// ParseBall accepts "ultra", "ultra-ball" or "Ultra Ball".
+func ParseBall(name string) (Ball, error) {
+	name = strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), " ", "-")
+	if !strings.HasSuffix(name, "-ball") {
+		name += "-ball"
+	}
+	for _, b := range Balls {
+		if string(b) == name {
+			return b, nil
+		}
+	}
+	return "", fmt.Errorf("unknown ball %q", name)
+}
+
+// Description summarizes when the ball works well.
+func (b Ball) Description() string {
+	return ballDescriptions[b]
+}
+
+// Conditions are the circumstances of a throw that some balls reward.
+type Conditions struct {
+	// Types of the wild Pokemon.
+	Types []string
+	// Level of the wild Pokemon, 0 when unknown.
+	Level int
+	// Throws made at this Pokemon before this one.
+	Throws int
+	// AlreadyCaught is set when the species is in the Pokedex.
+	AlreadyCaught bool
+	// Dark is set at night and in caves.
+	Dark bool
+	// Water is set when the Pokemon was found surfing or fishing.
+	Water bool
+}
+
+// Modifier returns the ball's catch rate multiplier under c.
+func (b Ball) Modifier(c Conditions) float64 {
+	switch b {
+	case GreatBall:
+		return 1.5
+	case UltraBall:
+		return 2
+	case MasterBall:
+		return 255
+	case NetBall:
+		for _, t := range c.Types {
+			if t == "water" || t == "bug" {
+				return 3
+			}
+		}
+	case NestBall:
+		if c.Level > 0 && c.Level < 30 {
+			return float64(40-c.Level) / 10
+		}
+	case RepeatBall:
+		if c.AlreadyCaught {
+			return 3
+		}
+	case TimerBall:
+		return math.Min(float64(c.Throws+10)/10, 4)
+	case QuickBall:
+		if c.Throws == 0 {
+			return 4
+		}
+	case DuskBall:
+		if c.Dark {
+			return 3.5
+		}
+	case DiveBall:
+		if c.Water {
+			return 3.5
+		}
+	}
+	return 1
+}
+
+// findWeights are the relative odds of finding each ball while exploring.
+var findWeights = []struct {
+	ball   Ball
+	weight int
+}{
+	{PokeBall, 400}, {GreatBall, 200}, {UltraBall, 80}, {PremierBall, 40},
+	{LuxuryBall, 30}, {NetBall, 40}, {NestBall, 40}, {RepeatBall, 40},
+	{TimerBall, 40}, {QuickBall, 30}, {DuskBall, 30}, {DiveBall, 30},
+	{MasterBall, 2},
+}
+
+// Find rolls a ball found lying around, and how many of it.
+func Find(rng *rand.Rand) (Ball, int) {
+	total := 0
+	for _, f := range findWeights {
+		total += f.weight
+	}
+	roll := rng.Intn(total)
+	for _, f := range findWeights {
+		if roll < f.weight {
+			if f.ball == PokeBall || f.ball == GreatBall {
+				return f.ball, 1 + rng.Intn(3)
+			}
+			return f.ball, 1
+		}
+		roll -= f.weight
+	}
+	return PokeBall, 1
+}
@@ -0,0 +1,100 @@
+// Package capture models throwing a ball at a wild Pokemon, following the
+// capture formula of the third and fourth generation games.
+package capture
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+)
+
+// Status is a non-volatile status condition of the wild Pokemon.
+type Status string
+
+const (
+	None      Status = "none"
+	Sleep     Status = "sleep"
+	Freeze    Status = "freeze"
+	Paralysis Status = "paralysis"
+	Burn      Status = "burn"
+	Poison    Status = "poison"
+)
+
+// Statuses lists every status condition.
+var Statuses = []Status{None, Sleep, Freeze, Paralysis, Burn, Poison}
+
+// ParseStatus validates a status name. The empty string means None.
+func ParseStatus(name string) (Status, error) {
+	if name == "" {
+		return None, nil
+	}
+	for _, s := range Statuses {
+		if string(s) == strings.ToLower(name) {
+			return s, nil
+		}
+	}
+	return "", fmt.Errorf("unknown status %q (want none, sleep, freeze, paralysis, burn or poison)", name)
+}
+
+// Modifier is the status bonus to the catch rate.
+func (s Status) Modifier() float64 {
+	switch s {
+	case Sleep, Freeze:
+		return 2
+	case Paralysis, Burn, Poison:
+		return 1.5
+	}
+	return 1
+}
+
+// Shakes is the number of shake checks a throw must pass.
+const Shakes = 4
+
+// Attempt describes one throw.
+type Attempt struct {
+	// CaptureRate is the species' capture_rate, from 0 to 255.
+	CaptureRate int
+	// HP is the fraction of its maximum HP the Pokemon has left.
+	HP float64
+	// Ball is the ball's catch rate modifier, 1 for a Poke Ball.
+	Ball   float64
+	Status Status
+}
+
+// rate returns the modified catch rate a of the games, where 255 or more
+// is a guaranteed catch.
+func (a Attempt) rate() float64 {
+	hp := math.Min(math.Max(a.HP, 0), 1)
+	return (1 - 2*hp/3) * float64(a.CaptureRate) * a.Ball * a.Status.Modifier()
+}
+
+// shakeThreshold returns b, the value a random number below 65536 must stay
+// under for the ball to shake once.
+func (a Attempt) shakeThreshold() float64 {
+	rate := a.rate()
+	if rate <= 0 {
+		return 0
+	}
+	if rate >= 255 {
+		return 65536
+	}
+	return math.Floor(1048560 / math.Sqrt(math.Sqrt(16711680/rate)))
+}
+
+// Chance returns the probability, from 0 to 1, that the throw succeeds.
+func (a Attempt) Chance() float64 {
+	return math.Min(math.Pow(a.shakeThreshold()/65536, Shakes), 1)
+}
+
+// Throw rolls the shake checks with rng. It returns how many checks passed
+// and whether the Pokemon was caught.
+func (a Attempt) Throw(rng *rand.Rand) (shakes int, caught bool) {
+	b := a.shakeThreshold()
+	for shakes = 0; shakes < Shakes; shakes++ {
+		if float64(rng.Intn(65536)) >= b {
+			return shakes, false
+		}
+	}
+	return shakes, true
+}
@@ -0,0 +1,106 @@
+package capture
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestChance(t *testing.T) {
+	cases := []struct {
+		name    string
+		attempt Attempt
+		want    float64
+	}{
+		{"zero capture rate", Attempt{CaptureRate: 0, HP: 1, Ball: 1, Status: None}, 0},
+		{"full hp pidgey", Attempt{CaptureRate: 255, HP: 1, Ball: 1, Status: None}, 0.3326},
+		{"weakened pikachu", Attempt{CaptureRate: 190, HP: 0.1, Ball: 1, Status: None}, 0.6954},
+		{"asleep gyarados", Attempt{CaptureRate: 45, HP: 1, Ball: 1, Status: Sleep}, 0.1176},
+		{"guaranteed", Attempt{CaptureRate: 3, HP: 1, Ball: 255, Status: None}, 1},
+	}
+	for _, c := range cases {
+		if got := c.attempt.Chance(); math.Abs(got-c.want) > 0.001 {
+			t.Errorf("%s: Chance() = %.4f, want %.4f", c.name, got, c.want)
+		}
+	}
+}
+
+func TestThrowMatchesChance(t *testing.T) {
+	attempt := Attempt{CaptureRate: 190, HP: 1, Ball: 1, Status: None}
+	rng := rand.New(rand.NewSource(1))
+	caught := 0
+	const throws = 20000
+	for i := 0; i < throws; i++ {
+		shakes, ok := attempt.Throw(rng)
+		if ok != (shakes == Shakes) {
+			t.Fatalf("Throw() = %d, %v", shakes, ok)
+		}
+		if ok {
+			caught++
+		}
+	}
+	if got, want := float64(caught)/throws, attempt.Chance(); math.Abs(got-want) > 0.02 {
+		t.Errorf("caught %.3f of throws, want about %.3f", got, want)
+	}
+}
+
+func TestThrowIsDeterministic(t *testing.T) {
+	attempt := Attempt{CaptureRate: 45, HP: 0.5, Ball: 1.5, Status: Paralysis}
+	a, b := rand.New(rand.NewSource(4)), rand.New(rand.NewSource(4))
+	for i := 0; i < 100; i++ {
+		s1, ok1 := attempt.Throw(a)
+		s2, ok2 := attempt.Throw(b)
+		if s1 != s2 || ok1 != ok2 {
+			t.Fatalf("throw %d differs with the same seed", i)
+		}
+	}
+}
+
+func TestParseStatus(t *testing.T) {
+	if s, err := ParseStatus(""); err != nil || s != None {
+		t.Errorf("ParseStatus(\"\") = %q, %v", s, err)
+	}
+	if s, err := ParseStatus("Sleep"); err != nil || s != Sleep {
+		t.Errorf("ParseStatus(\"Sleep\") = %q, %v", s, err)
+	}
+	if _, err := ParseStatus("confused"); err == nil {
+		t.Error("expected an error for an unknown status")
+	}
+}
+
+func TestBallModifier(t *testing.T) {
+	cases := []struct {
+		ball Ball
+		cond Conditions
+		want float64
+	}{
+		{PokeBall, Conditions{}, 1},
+		{UltraBall, Conditions{}, 2},
+		{NetBall, Conditions{Types: []string{"normal", "flying"}}, 1},
+		{NetBall, Conditions{Types: []string{"bug", "poison"}}, 3},
+		{NestBall, Conditions{Level: 5}, 3.5},
+		{NestBall, Conditions{}, 1},
+		{TimerBall, Conditions{Throws: 5}, 1.5},
+		{TimerBall, Conditions{Throws: 50}, 4},
+		{QuickBall, Conditions{Throws: 0}, 4},
+		{QuickBall, Conditions{Throws: 1}, 1},
+		{RepeatBall, Conditions{AlreadyCaught: true}, 3},
+		{DuskBall, Conditions{Dark: true}, 3.5},
+	}
+	for _, c := range cases {
+		if got := c.ball.Modifier(c.cond); got != c.want {
+			t.Errorf("%s.Modifier(%+v) = %v, want %v", c.ball, c.cond, got, c.want)
+		}
+	}
+}
+
+func TestParseBall(t *testing.T) {
+	for _, name := range []string{"ultra", "ultra-ball", "Ultra Ball"} {
+		if b, err := ParseBall(name); err != nil || b != UltraBall {
+			t.Errorf("ParseBall(%q) = %q, %v", name, b, err)
+		}
+	}
+	if _, err := ParseBall("beast"); err == nil {
+		t.Error("expected an error for an unknown ball")
+	}
+}
@@ -0,0 +1,279 @@
+// Package diskcache is a persistent, size-capped key/value store used as
+// the second tier behind the in-memory cache.
+package diskcache
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// fileExt names entry files. The cache leaves any other file in its
+// directory alone.
+const fileExt = ".entry"
+
+// Cache stores entries as individual files in a directory. Each entry has
+// its own expiry; when the total size exceeds the cap the least recently
+// used entries are evicted.
+type Cache struct {
+	dir      string
+	maxBytes int64
+
+	mu      sync.Mutex
+	entries map[string]*entry
+	size    int64
+	stats   Stats
+}
+
+// Stats describes the contents of a Cache and how many entries it evicted.
+type Stats struct {
+	Entries   int
+	Bytes     int64
+	MaxBytes  int64
+	Evictions int
+}
+
+type entry struct {
+	file     string
+	size     int64
+	expires  time.Time
+	lastUsed time.Time
+}
+
+// header is the first line of an entry file, followed by the raw value.
+type header struct {
+	Key     string    `json:"key"`
+	Expires time.Time `json:"expires"`
+}
+
+// Open loads the index of an existing cache directory, creating it if
+// needed. maxBytes <= 0 means unbounded.
+func Open(dir string, maxBytes int64) (*Cache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	c := &Cache{
+		dir:      dir,
+		maxBytes: maxBytes,
+		entries:  make(map[string]*entry),
+	}
+
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range files {
+		path := filepath.Join(dir, f.Name())
+		if f.IsDir() || !strings.HasSuffix(f.Name(), fileExt) {
+			continue
+		}
+		h, err := readHeader(path)
+		if err != nil {
+			os.Remove(path)
+			continue
+		}
+		info, err := f.Info()
+		if err != nil {
+			continue
+		}
+		c.entries[h.Key] = &entry{
+			file:     path,
+			size:     info.Size(),
+			expires:  h.Expires,
+			lastUsed: info.ModTime(),
+		}
+		c.size += info.Size()
+	}
+	c.evict()
+	return c, nil
+}
+
+// Dir returns the directory backing the cache.
+func (c *Cache) Dir() string {
+	return c.dir
+}
+
+// Get returns the value stored for key if it exists and has not expired.
+func (c *Cache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(e.expires) {
+		c.remove(key, e)
+		return nil, false
+	}
+	val, err := readValue(e.file)
+	if err != nil {
+		c.remove(key, e)
+		return nil, false
+	}
+
+	now := time.Now()
+	e.lastUsed = now
+	os.Chtimes(e.file, now, now)
+	return val, true
+}
+
+// GetStale returns the value stored for key even if it has expired. It is
+// meant for offline use, where old data beats no data.
+func (c *Cache) GetStale(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	val, err := readValue(e.file)
+	if err != nil {
+		c.remove(key, e)
+		return nil, false
+	}
+	return val, true
+}
+
+// Add stores val under key for ttl, replacing any previous value.
+func (c *Cache) Add(key string, val []byte, ttl time.Duration) error {
+	h, err := json.Marshal(header{Key: key, Expires: time.Now().Add(ttl)})
+	if err != nil {
+		return err
+	}
+	raw := append(append(h, '\n'), val...)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	path := filepath.Join(c.dir, fileName(key))
+	tmp, err := os.CreateTemp(c.dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(raw); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return err
+	}
+
+	if old, ok := c.entries[key]; ok {
+		c.size -= old.size
+	}
+	c.entries[key] = &entry{
+		file:     path,
+		size:     int64(len(raw)),
+		expires:  time.Now().Add(ttl),
+		lastUsed: time.Now(),
+	}
+	c.size += int64(len(raw))
+	c.evict()
+	return nil
+}
+
+// Clear removes every entry from the cache.
+func (c *Cache) Clear() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var firstErr error
+	for key, e := range c.entries {
+		if err := os.Remove(e.file); err != nil && !os.IsNotExist(err) && firstErr == nil {
+			firstErr = err
+		}
+		delete(c.entries, key)
+	}
+	c.size = 0
+	return firstErr
+}
+
+// Stats returns a snapshot of the cache counters.
+func (c *Cache) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s := c.stats
+	s.Entries = len(c.entries)
+	s.Bytes = c.size
+	s.MaxBytes = c.maxBytes
+	return s
+}
+
+// evict drops least recently used entries until the cache fits its cap.
+// c.mu must be held.
+func (c *Cache) evict() {
+	if c.maxBytes <= 0 || c.size <= c.maxBytes {
+		return
+	}
+	keys := make([]string, 0, len(c.entries))
+	for key := range c.entries {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return c.entries[keys[i]].lastUsed.Before(c.entries[keys[j]].lastUsed)
+	})
+	for _, key := range keys {
+		if c.size <= c.maxBytes {
+			break
+		}
+		c.remove(key, c.entries[key])
+		c.stats.Evictions++
+	}
+}
+
+// remove deletes an entry and its file. c.mu must be held.
+func (c *Cache) remove(key string, e *entry) {
+	os.Remove(e.file)
+	c.size -= e.size
+	delete(c.entries, key)
+}
+
+func fileName(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:]) + fileExt
+}
+
+// readHeader reads the first line of an entry file.
+func readHeader(path string) (header, error) {
+	var h header
+	f, err := os.Open(path)
+	if err != nil {
+		return h, err
+	}
+	defer f.Close()
+	line, err := bufio.NewReader(f).ReadBytes('\n')
+	if err != nil {
+		return h, err
+	}
+	err = json.Unmarshal(line, &h)
+	return h, err
+}
+
+// readValue reads the value stored after the header of an entry file.
+func readValue(path string) ([]byte, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	i := bytes.IndexByte(raw, '\n')
+	if i < 0 {
+		return nil, errors.New("no header in " + path)
+	}
+	return raw[i+1:], nil
+}
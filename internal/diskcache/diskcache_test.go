@@ -0,0 +1,115 @@
+package diskcache
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAddGetSurvivesReopen(t *testing.T) {
+	dir := t.TempDir()
+	c, err := Open(dir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Add("https://example.com/a", []byte("alpha"), time.Hour); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err = Open(dir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	val, ok := c.Get("https://example.com/a")
+	if !ok || string(val) != "alpha" {
+		t.Fatalf("expected alpha after reopen, got %q, %v", val, ok)
+	}
+	if s := c.Stats(); s.Entries != 1 {
+		t.Errorf("unexpected stats: %+v", s)
+	}
+}
+
+func TestExpiredEntriesMiss(t *testing.T) {
+	c, err := Open(t.TempDir(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Add("k", []byte("v"), -time.Second)
+	if _, ok := c.Get("k"); ok {
+		t.Fatal("expected expired entry to miss")
+	}
+	if s := c.Stats(); s.Entries != 0 {
+		t.Errorf("unexpected stats: %+v", s)
+	}
+}
+
+func TestEvictsLeastRecentlyUsed(t *testing.T) {
+	c, err := Open(t.TempDir(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Add("a", make([]byte, 100), time.Hour)
+	entrySize := c.Stats().Bytes
+	c.maxBytes = entrySize * 2
+
+	c.Add("b", make([]byte, 100), time.Hour)
+	time.Sleep(10 * time.Millisecond)
+	c.Get("a")
+	c.Add("c", make([]byte, 100), time.Hour)
+
+	if _, ok := c.Get("b"); ok {
+		t.Error("expected b to be evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, ok := c.Get(key); !ok {
+			t.Errorf("expected %s to remain cached", key)
+		}
+	}
+	if s := c.Stats(); s.Evictions != 1 || s.Bytes > s.MaxBytes {
+		t.Errorf("unexpected stats: %+v", s)
+	}
+}
+
+func TestClear(t *testing.T) {
+	c, err := Open(t.TempDir(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Add("a", []byte("1"), time.Hour)
+	c.Add("b", []byte("2"), time.Hour)
+	if err := c.Clear(); err != nil {
+		t.Fatal(err)
+	}
+	if s := c.Stats(); s.Entries != 0 || s.Bytes != 0 {
+		t.Errorf("unexpected stats after clear: %+v", s)
+	}
+}
+
+func TestStoresRawValues(t *testing.T) {
+	dir := t.TempDir()
+	other := filepath.Join(dir, "notes.json")
+	os.WriteFile(other, []byte(`{"key":"k"}`), 0o644)
+	c, err := Open(dir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	val := []byte(`{"name":"pikachu"}`)
+	c.Add("k", val, time.Hour)
+
+	if _, err := os.Stat(other); err != nil {
+		t.Errorf("a file that is not an entry was touched: %v", err)
+	}
+	entries, _ := filepath.Glob(filepath.Join(dir, "*"+fileExt))
+	if len(entries) != 1 {
+		t.Fatalf("expected one entry, got %v", entries)
+	}
+	raw, _ := os.ReadFile(entries[0])
+	if !bytes.HasSuffix(raw, val) || int64(len(raw)) > int64(len(val))+100 {
+		t.Errorf("value is not stored as is: %q", raw)
+	}
+	if got, ok := c.Get("k"); !ok || !bytes.Equal(got, val) {
+		t.Errorf("Get = %q, %v", got, ok)
+	}
+}
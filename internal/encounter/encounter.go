@@ -0,0 +1,150 @@
+// Package encounter works out which wild Pokemon appear in a location area
+// for a game version and encounter method, and rolls random encounters.
+package encounter
+
+import (
+	"math/rand"
+
+	"github.com/anegri01f01/pokegocli/internal/pokeapi"
+)
+
+// Slot is a Pokemon that appears in an area with one method. PokeAPI may
+// list a Pokemon several times for the same method, e.g. once per level
+// range; a Slot sums up their chances and spans their levels.
+type Slot struct {
+	Pokemon string
+	Method  string
+	// Chance is the percentage of encounters with this method that are
+	// this Pokemon.
+	Chance   int
+	MinLevel int
+	MaxLevel int
+
+	entries []entry
+}
+
+type entry struct {
+	chance, minLevel, maxLevel int
+}
+
+// Encounter is a wild Pokemon that appeared.
+type Encounter struct {
+	Pokemon string
+	Level   int
+	Method  string
+}
+
+// Versions returns the versions with encounters in the area, in the order
+// PokeAPI lists them.
+func Versions(area pokeapi.LocationArea) []string {
+	var versions []string
+	seen := make(map[string]bool)
+	for _, pe := range area.PokemonEncounters {
+		for _, vd := range pe.VersionDetails {
+			if !seen[vd.Version.Name] {
+				seen[vd.Version.Name] = true
+				versions = append(versions, vd.Version.Name)
+			}
+		}
+	}
+	return versions
+}
+
+// Methods returns the encounter methods used in the area in a version.
+func Methods(area pokeapi.LocationArea, version string) []string {
+	var methods []string
+	seen := make(map[string]bool)
+	for _, slot := range Slots(area, version, "") {
+		if !seen[slot.Method] {
+			seen[slot.Method] = true
+			methods = append(methods, slot.Method)
+		}
+	}
+	return methods
+}
+
+// Slots returns the area's slots in a version, restricted to one method
+// unless method is empty.
+func Slots(area pokeapi.LocationArea, version, method string) []Slot {
+	var slots []Slot
+	index := make(map[[2]string]int)
+	for _, pe := range area.PokemonEncounters {
+		for _, vd := range pe.VersionDetails {
+			if vd.Version.Name != version {
+				continue
+			}
+			for _, d := range vd.EncounterDetails {
+				if method != "" && d.Method.Name != method {
+					continue
+				}
+				key := [2]string{pe.Pokemon.Name, d.Method.Name}
+				i, ok := index[key]
+				if !ok {
+					i = len(slots)
+					index[key] = i
+					slots = append(slots, Slot{
+						Pokemon:  pe.Pokemon.Name,
+						Method:   d.Method.Name,
+						MinLevel: d.MinLevel,
+						MaxLevel: d.MaxLevel,
+					})
+				}
+				s := &slots[i]
+				s.Chance += d.Chance
+				s.MinLevel = min(s.MinLevel, d.MinLevel)
+				s.MaxLevel = max(s.MaxLevel, d.MaxLevel)
+				s.entries = append(s.entries, entry{d.Chance, d.MinLevel, d.MaxLevel})
+			}
+		}
+	}
+	return slots
+}
+
+// Roll picks one of the slots, weighted by the chance of each of their
+// entries, and a level in that entry's range. It reports false when no
+// slot has any chance.
+func Roll(rng *rand.Rand, slots []Slot) (Encounter, bool) {
+	total := 0
+	for _, s := range slots {
+		total += s.Chance
+	}
+	if total <= 0 {
+		return Encounter{}, false
+	}
+
+	roll := rng.Intn(total)
+	for _, s := range slots {
+		for _, e := range s.entries {
+			if roll >= e.chance {
+				roll -= e.chance
+				continue
+			}
+			level := e.minLevel
+			if e.maxLevel > e.minLevel {
+				level += rng.Intn(e.maxLevel - e.minLevel + 1)
+			}
+			return Encounter{Pokemon: s.Pokemon, Level: level, Method: s.Method}, true
+		}
+	}
+	return Encounter{}, false
+}
+
+// MethodRate is how often an encounter method finds a Pokemon at all, e.g.
+// per step walking through tall grass.
+type MethodRate struct {
+	Method string
+	Rate   int
+}
+
+// MethodRates returns the area's encounter method rates in a version.
+func MethodRates(area pokeapi.LocationArea, version string) []MethodRate {
+	var rates []MethodRate
+	for _, emr := range area.EncounterMethodRates {
+		for _, vd := range emr.VersionDetails {
+			if vd.Version.Name == version {
+				rates = append(rates, MethodRate{Method: emr.EncounterMethod.Name, Rate: vd.Rate})
+			}
+		}
+	}
+	return rates
+}
@@ -0,0 +1,93 @@
+package encounter
+
+import (
+	"context"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/anegri01f01/pokegocli/internal/fixtures"
+	"github.com/anegri01f01/pokegocli/internal/pokeapi"
+)
+
+func fixtureArea(t *testing.T, name string) pokeapi.LocationArea {
+	t.Helper()
+	srv := fixtures.NewServer()
+	t.Cleanup(srv.Close)
+	client := pokeapi.NewClient(pokeapi.WithBaseURL(srv.BaseURL()))
+	area, err := client.GetLocationArea(context.Background(), name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return area
+}
+
+func TestSlotsAggregateDuplicates(t *testing.T) {
+	area := fixtureArea(t, "kanto-route-1-area")
+
+	got := Slots(area, "firered", "walk")
+	want := []Slot{
+		{Pokemon: "pidgey", Method: "walk", Chance: 55, MinLevel: 2, MaxLevel: 7},
+		{Pokemon: "rattata", Method: "walk", Chance: 45, MinLevel: 2, MaxLevel: 4},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Slots() = %+v", got)
+	}
+	for i := range want {
+		got[i].entries = nil
+		if !reflect.DeepEqual(got[i], want[i]) {
+			t.Errorf("slot %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	if got := Slots(area, "emerald", ""); len(got) != 0 {
+		t.Errorf("expected no slots for a version without encounters, got %+v", got)
+	}
+}
+
+func TestVersionsAndMethods(t *testing.T) {
+	area := fixtureArea(t, "canalave-city-area")
+	if got, want := Versions(area), []string{"diamond", "pearl", "platinum"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Versions() = %v, want %v", got, want)
+	}
+	if got, want := Methods(area, "pearl"), []string{"surf", "old-rod", "good-rod"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Methods() = %v, want %v", got, want)
+	}
+}
+
+func TestRollFollowsChances(t *testing.T) {
+	area := fixtureArea(t, "kanto-route-1-area")
+	slots := Slots(area, "firered", "walk")
+	rng := rand.New(rand.NewSource(1))
+
+	counts := make(map[string]int)
+	for i := 0; i < 10000; i++ {
+		enc, ok := Roll(rng, slots)
+		if !ok {
+			t.Fatal("Roll found nothing")
+		}
+		if enc.Pokemon == "rattata" && (enc.Level < 2 || enc.Level > 4) {
+			t.Fatalf("rattata rolled at level %d", enc.Level)
+		}
+		counts[enc.Pokemon]++
+	}
+	if counts["pidgey"] < 5200 || counts["pidgey"] > 5800 {
+		t.Errorf("pidgey appeared %d times in 10000, want about 5500", counts["pidgey"])
+	}
+
+	if _, ok := Roll(rng, nil); ok {
+		t.Error("expected no encounter without slots")
+	}
+}
+
+func TestMethodRates(t *testing.T) {
+	area := fixtureArea(t, "hoenn-route-104-area")
+	got := MethodRates(area, "emerald")
+	want := []MethodRate{{"walk", 20}, {"surf", 4}, {"old-rod", 30}, {"good-rod", 60}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("MethodRates() = %v, want %v", got, want)
+	}
+	if got := MethodRates(area, "red"); got != nil {
+		t.Errorf("expected no rates for red, got %v", got)
+	}
+}
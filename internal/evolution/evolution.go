@@ -0,0 +1,204 @@
+// Package evolution reads PokeAPI evolution chains: what a species evolves
+// into, how, and whether a Pokemon meets the conditions.
+package evolution
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/anegri01f01/pokegocli/internal/i18n"
+	"github.com/anegri01f01/pokegocli/internal/pokeapi"
+)
+
+// Genders as PokeAPI numbers them in evolution conditions.
+const (
+	female = 1
+	male   = 2
+)
+
+// Node is a species in an evolution tree.
+type Node struct {
+	Species string
+	IsBaby  bool
+	// Conditions describe the ways to evolve into this species, one per
+	// alternative. The root has none.
+	Conditions []string
+	EvolvesTo  []Node
+}
+
+// Tree turns a chain into a tree with conditions readable in lang.
+func Tree(lang string, link pokeapi.ChainLink) Node {
+	n := Node{Species: link.Species.Name, IsBaby: link.IsBaby}
+	for _, d := range link.EvolutionDetails {
+		n.Conditions = append(n.Conditions, Describe(lang, d))
+	}
+	for _, next := range link.EvolvesTo {
+		n.EvolvesTo = append(n.EvolvesTo, Tree(lang, next))
+	}
+	return n
+}
+
+// Next returns the links a species evolves into, or nil when it is not in
+// the chain or does not evolve.
+func Next(chain pokeapi.EvolutionChain, species string) []pokeapi.ChainLink {
+	if link := find(chain.Chain, species); link != nil {
+		return link.EvolvesTo
+	}
+	return nil
+}
+
+func find(link pokeapi.ChainLink, species string) *pokeapi.ChainLink {
+	if link.Species.Name == species {
+		return &link
+	}
+	for _, next := range link.EvolvesTo {
+		if found := find(next, species); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// Describe writes out one set of evolution conditions in lang, e.g.
+// "level 16", "use thunder-stone" or "trade holding metal-coat".
+func Describe(lang string, d pokeapi.EvolutionDetail) string {
+	var parts []string
+	add := func(format string, args ...any) { parts = append(parts, i18n.Sprintf(lang, format, args...)) }
+	switch d.Trigger.Name {
+	case "level-up":
+		if d.MinLevel != nil {
+			add("level %d", *d.MinLevel)
+		} else {
+			add("level up")
+		}
+	case "use-item":
+		if d.Item != nil {
+			add("use %s", d.Item.Name)
+		}
+	case "trade":
+		add("trade")
+	default:
+		parts = append(parts, d.Trigger.Name)
+		if d.MinLevel != nil {
+			add("at level %d", *d.MinLevel)
+		}
+	}
+	if d.Trigger.Name != "use-item" && d.Item != nil {
+		add("using %s", d.Item.Name)
+	}
+	if d.HeldItem != nil {
+		add("holding %s", d.HeldItem.Name)
+	}
+	if d.TradeSpecies != nil {
+		add("for %s", d.TradeSpecies.Name)
+	}
+	if d.MinHappiness != nil {
+		add("with friendship %d", *d.MinHappiness)
+	}
+	if d.MinAffection != nil {
+		add("with affection %d", *d.MinAffection)
+	}
+	if d.MinBeauty != nil {
+		add("with beauty %d", *d.MinBeauty)
+	}
+	if d.KnownMove != nil {
+		add("knowing %s", d.KnownMove.Name)
+	}
+	if d.KnownMoveType != nil {
+		add("knowing a %s move", d.KnownMoveType.Name)
+	}
+	if d.Gender != nil {
+		add("if %s", i18n.T(lang, genderName(*d.Gender)))
+	}
+	if d.TimeOfDay != "" {
+		parts = append(parts, i18n.T(lang, "at "+d.TimeOfDay))
+	}
+	if d.Location != nil {
+		add("at %s", d.Location.Name)
+	}
+	if d.NeedsOverworldRain {
+		add("in the rain")
+	}
+	if d.PartySpecies != nil {
+		add("with %s in the party", d.PartySpecies.Name)
+	}
+	if d.PartyType != nil {
+		add("with a %s type in the party", d.PartyType.Name)
+	}
+	if d.RelativePhysicalStats != nil {
+		add("if attack %s defense", []string{"<", "=", ">"}[*d.RelativePhysicalStats+1])
+	}
+	if d.TurnUpsideDown {
+		add("holding the console upside down")
+	}
+	return strings.Join(parts, " ")
+}
+
+func genderName(gender int) string {
+	if gender == female {
+		return "female"
+	}
+	return "male"
+}
+
+// Subject is what is known about a Pokemon that wants to evolve.
+type Subject struct {
+	Level      int
+	Friendship int
+	Gender     string
+	// Moves are the moves it knows, and MoveTypes their types.
+	Moves     []string
+	MoveTypes []string
+	// Items are the items in the bag, by name.
+	Items map[string]int
+	// TimeOfDay is "day" or "night".
+	TimeOfDay string
+}
+
+// Check reports why a Pokemon does not meet one set of evolution
+// conditions, or nil when it does. Conditions the Pokedex cannot meet,
+// like trades or held items, are never met.
+func Check(d pokeapi.EvolutionDetail, s Subject) error {
+	var missing []string
+	switch d.Trigger.Name {
+	case "level-up", "use-item":
+	case "trade":
+		return errors.New("it evolves when traded, which the Pokedex cannot do")
+	default:
+		return fmt.Errorf("it evolves by %s, which the Pokedex cannot do", d.Trigger.Name)
+	}
+
+	if d.MinLevel != nil && s.Level < *d.MinLevel {
+		missing = append(missing, fmt.Sprintf("level %d (it is level %d)", *d.MinLevel, s.Level))
+	}
+	if d.Item != nil && s.Items[d.Item.Name] <= 0 {
+		missing = append(missing, "a "+d.Item.Name+" in the bag")
+	}
+	if d.MinHappiness != nil && s.Friendship < *d.MinHappiness {
+		missing = append(missing, fmt.Sprintf("friendship %d (it has %d)", *d.MinHappiness, s.Friendship))
+	}
+	if d.KnownMove != nil && !slices.Contains(s.Moves, d.KnownMove.Name) {
+		missing = append(missing, "to know "+d.KnownMove.Name)
+	}
+	if d.KnownMoveType != nil && !slices.Contains(s.MoveTypes, d.KnownMoveType.Name) {
+		missing = append(missing, "to know a "+d.KnownMoveType.Name+" move")
+	}
+	if d.Gender != nil && s.Gender != genderName(*d.Gender) {
+		missing = append(missing, "to be "+genderName(*d.Gender))
+	}
+	if d.TimeOfDay != "" && d.TimeOfDay != s.TimeOfDay {
+		missing = append(missing, "to be "+d.TimeOfDay+" time")
+	}
+	if len(missing) > 0 {
+		return errors.New("it needs " + strings.Join(missing, ", "))
+	}
+
+	if d.HeldItem != nil || d.Location != nil || d.MinAffection != nil || d.MinBeauty != nil ||
+		d.NeedsOverworldRain || d.PartySpecies != nil || d.PartyType != nil ||
+		d.RelativePhysicalStats != nil || d.TurnUpsideDown {
+		return errors.New("it evolves " + Describe(i18n.English, d) + ", which the Pokedex cannot check")
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package evolution
+
+import (
+	"context"
+	"testing"
+
+	"github.com/anegri01f01/pokegocli/internal/fixtures"
+	"github.com/anegri01f01/pokegocli/internal/i18n"
+	"github.com/anegri01f01/pokegocli/internal/pokeapi"
+)
+
+func fixtureChain(t *testing.T, species string) pokeapi.EvolutionChain {
+	t.Helper()
+	srv := fixtures.NewServer()
+	t.Cleanup(srv.Close)
+	client := pokeapi.NewClient(pokeapi.WithBaseURL(srv.BaseURL()))
+	chain, err := client.GetSpeciesEvolutionChain(context.Background(), species)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return chain
+}
+
+func TestTree(t *testing.T) {
+	tree := Tree(i18n.English, fixtureChain(t, "pikachu").Chain)
+	if tree.Species != "pichu" || !tree.IsBaby || len(tree.Conditions) != 0 {
+		t.Fatalf("unexpected root %+v", tree)
+	}
+	pikachu := tree.EvolvesTo[0]
+	if pikachu.Species != "pikachu" || pikachu.Conditions[0] != "level up with friendship 220" {
+		t.Errorf("unexpected pikachu %+v", pikachu)
+	}
+	if raichu := pikachu.EvolvesTo[0]; raichu.Conditions[0] != "use thunder-stone" || len(raichu.EvolvesTo) != 0 {
+		t.Errorf("unexpected raichu %+v", raichu)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	chain := fixtureChain(t, "pidgey")
+	next := Next(chain, "pidgeotto")
+	if len(next) != 1 || next[0].Species.Name != "pidgeot" {
+		t.Fatalf("Next(pidgeotto) = %+v", next)
+	}
+	if got := Next(chain, "pidgeot"); len(got) != 0 {
+		t.Errorf("pidgeot should not evolve, got %+v", got)
+	}
+
+	detail := next[0].EvolutionDetails[0]
+	if err := Check(detail, Subject{Level: 35}); err == nil || err.Error() != "it needs level 36 (it is level 35)" {
+		t.Errorf("Check at level 35 = %v", err)
+	}
+	if err := Check(detail, Subject{Level: 36}); err != nil {
+		t.Errorf("Check at level 36 = %v", err)
+	}
+
+	trade := pokeapi.EvolutionDetail{Trigger: pokeapi.NamedResource{Name: "trade"}, HeldItem: &pokeapi.NamedResource{Name: "metal-coat"}}
+	if got := Describe(i18n.English, trade); got != "trade holding metal-coat" {
+		t.Errorf("Describe(trade) = %q", got)
+	}
+	if got := Describe("ja", trade); got != "つうしんこうかん metal-coat を もたせて" {
+		t.Errorf("Describe(ja, trade) = %q", got)
+	}
+	if err := Check(trade, Subject{Level: 100}); err == nil {
+		t.Error("a trade evolution should never be met")
+	}
+}
@@ -0,0 +1,167 @@
+// Package fixtures serves canned PokeAPI responses so the Pokedex can run
+// and be tested without network access.
+package fixtures
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// APIPath is the path prefix the fixtures are served under, mirroring PokeAPI.
+const APIPath = "/api/v2"
+
+const basePlaceholder = "{{BASE}}"
+
+//go:embed data
+var data embed.FS
+
+// Server is a local PokeAPI stand-in backed by the bundled fixtures.
+type Server struct {
+	*httptest.Server
+}
+
+// NewServer starts a fixture server on a loopback port.
+func NewServer() *Server {
+	s := &Server{}
+	s.Server = httptest.NewServer(Handler(func() string { return s.BaseURL() }))
+	return s
+}
+
+// BaseURL is the API root to hand to a pokeapi.Client.
+func (s *Server) BaseURL() string {
+	return s.URL + APIPath
+}
+
+// Handler serves the fixtures. baseURL is substituted into the resource
+// URLs embedded in each payload.
+func Handler(baseURL func() string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		rest, ok := strings.CutPrefix(r.URL.Path, APIPath+"/")
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		resource, name, _ := strings.Cut(strings.Trim(rest, "/"), "/")
+
+		var body []byte
+		var err error
+		if name == "" {
+			body, err = list(baseURL(), resource, r)
+		} else {
+			body, err = lookup(resource, name)
+		}
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(bytes.ReplaceAll(body, []byte(basePlaceholder), []byte(baseURL())))
+	})
+}
+
+type entry struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// entries returns every fixture of a resource, ordered by id like PokeAPI.
+func entries(resource string) ([]entry, error) {
+	files, err := fs.ReadDir(data, path.Join("data", resource))
+	if err != nil {
+		return nil, err
+	}
+	var out []entry
+	for _, f := range files {
+		raw, err := data.ReadFile(path.Join("data", resource, f.Name()))
+		if err != nil {
+			return nil, err
+		}
+		var e entry
+		if err := json.Unmarshal(raw, &e); err != nil {
+			return nil, fmt.Errorf("fixture %s/%s: %w", resource, f.Name(), err)
+		}
+		out = append(out, e)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out, nil
+}
+
+// lookup finds a fixture by name or id. Resources without names, like
+// evolution chains, are stored under their id.
+func lookup(resource, name string) ([]byte, error) {
+	if id, err := strconv.Atoi(name); err == nil {
+		all, err := entries(resource)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range all {
+			if e.ID == id && e.Name != "" {
+				name = e.Name
+				break
+			}
+		}
+	}
+	return data.ReadFile(path.Join("data", resource, name+".json"))
+}
+
+func list(baseURL, resource string, r *http.Request) ([]byte, error) {
+	all, err := entries(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	offset := queryInt(r, "offset", 0)
+	limit := queryInt(r, "limit", 20)
+	pageURL := func(offset int) string {
+		return fmt.Sprintf("%s/%s?offset=%d&limit=%d", baseURL, resource, offset, limit)
+	}
+
+	type result struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	}
+	page := struct {
+		Count    int      `json:"count"`
+		Next     *string  `json:"next"`
+		Previous *string  `json:"previous"`
+		Results  []result `json:"results"`
+	}{Count: len(all), Results: []result{}}
+
+	for i := offset; i < len(all) && i < offset+limit; i++ {
+		page.Results = append(page.Results, result{
+			Name: all[i].Name,
+			URL:  fmt.Sprintf("%s/%s/%d/", baseURL, resource, all[i].ID),
+		})
+	}
+	if offset+limit < len(all) {
+		next := pageURL(offset + limit)
+		page.Next = &next
+	}
+	if offset > 0 {
+		prev := pageURL(max(offset-limit, 0))
+		page.Previous = &prev
+	}
+	return json.Marshal(page)
+}
+
+func queryInt(r *http.Request, key string, fallback int) int {
+	n, err := strconv.Atoi(r.URL.Query().Get(key))
+	if err != nil || n < 0 {
+		return fallback
+	}
+	return n
+}
@@ -0,0 +1,71 @@
+package fixtures
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestServerPaginatesAndRewritesURLs(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	res, err := http.Get(srv.BaseURL() + "/location-area?limit=2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	var page struct {
+		Count    int
+		Next     *string
+		Previous *string
+		Results  []struct{ Name, URL string }
+	}
+	if err := json.NewDecoder(res.Body).Decode(&page); err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Results) != 2 || page.Next == nil || page.Previous != nil {
+		t.Fatalf("unexpected page: %+v", page)
+	}
+	if !strings.HasPrefix(page.Results[0].URL, srv.BaseURL()) {
+		t.Errorf("result URL %q not rewritten to %q", page.Results[0].URL, srv.BaseURL())
+	}
+}
+
+func TestServerLooksUpByNameAndID(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	for _, path := range []string{"/pokemon/pikachu", "/pokemon/25/"} {
+		res, err := http.Get(srv.BaseURL() + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var p struct{ Name string }
+		json.NewDecoder(res.Body).Decode(&p)
+		res.Body.Close()
+		if res.StatusCode != http.StatusOK || p.Name != "pikachu" {
+			t.Errorf("%s: status %d, name %q", path, res.StatusCode, p.Name)
+		}
+	}
+
+	res, err := http.Get(srv.BaseURL() + "/evolution-chain/10/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		t.Errorf("unnamed resources should be found by id, got %d", res.StatusCode)
+	}
+
+	res, err = http.Get(srv.BaseURL() + "/pokemon/missingno")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", res.StatusCode)
+	}
+}
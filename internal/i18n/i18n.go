@@ -0,0 +1,874 @@
+// Package i18n translates the Pokedex's own messages. Names of Pokemon,
+// places and the like come localized from PokeAPI instead.
+package i18n
+
+import (
+	"fmt"
+	"strings"
+)
+
+// English is the language the messages are written in.
+const English = "en"
+
+// T returns the translation of an English message, or the message itself
+// when the language has none. Messages are keyed by their English text.
+func T(lang, msg string) string {
+	if s, ok := catalog[lang][msg]; ok {
+		return s
+	}
+	return msg
+}
+
+// Sprintf translates a format string and formats it. Translations refer
+// to the arguments by index when they need another order.
+func Sprintf(lang, format string, args ...any) string {
+	return fmt.Sprintf(T(lang, format), args...)
+}
+
+// Translated reports whether there are translations for lang. Messages
+// in other languages are shown in English.
+func Translated(lang string) bool {
+	_, ok := catalog[lang]
+	return ok
+}
+
+// Title is the English display name of a PokeAPI slug, e.g. "Thunder Stone"
+// for thunder-stone.
+func Title(slug string) string {
+	words := strings.FieldsFunc(slug, func(r rune) bool { return r == '-' })
+	for i, w := range words {
+		words[i] = strings.ToUpper(w[:1]) + w[1:]
+	}
+	return strings.Join(words, " ")
+}
+
+var catalog = map[string]map[string]string{
+	"ja": {
+		"Pokedex > ":                "ずかん > ",
+		"you're on the first page":  "最初のページです",
+		"you're on the last page":   "最後のページです",
+		"Exploring %s (%s)...":      "%s (%s) を たんけん中...",
+		"Found Pokemon:":            "見つかったポケモン:",
+		"Encounter rates: %s":       "出現率: %s",
+		"Looking for pokemon by %s": "%s でポケモンをさがしています",
+		"You found %d %s!":          "%[2]s を %[1]d こ 見つけた!",
+		"Name: %s":                  "なまえ: %s",
+		"Genus: %s":                 "分類: %s",
+		"Height: %s":                "たかさ: %s",
+		"Weight: %s":                "おもさ: %s",
+		"Generation: %s":            "世代: %s",
+		"Habitat: %s":               "生息地: %s",
+		"Color: %s":                 "色: %s",
+		"Egg groups: %s":            "タマゴグループ: %s",
+		"Gender: %s":                "性別: %s",
+		"Capture rate: %d":          "捕獲率: %d",
+		"Pokedex (%s): %s":          "図鑑 (%s): %s",
+		"Stats:":                    "能力:",
+		"Types:":                    "タイプ:",
+		"Abilities:":                "特性:",
+		"hidden":                    "隠れ特性",
+		"genderless":                "性別不明",
+		"%s male, %s female":        "オス %s、メス %s",
+		"Your Pokedex is empty":     "ずかんは まだ からっぽです",
+
+		"A wild %s (Lv. %d) appeared!":          "あ! やせいの %s (Lv. %d) が とびだしてきた!",
+		"%s has a %.1f%% chance to be caught":   "%s を つかまえる確率は %.1f%% です",
+		"Throwing %s at %s...":                  "%[2]s に %[1]s を なげた...",
+		"The ball shook %d time(s)...":          "ボールが %d かい ゆれた...",
+		"%s was caught! It is #%d in your list": "やったー! %s を つかまえたぞ! リストの #%d です",
+		"%s escaped!":                           "%s に にげられた!",
+		"%s left: %d":                           "のこりの %s: %d",
+
+		"male":                                "オス",
+		"female":                              "メス",
+		"shiny":                               "色違い",
+		"You have not caught any pokemon yet": "まだ ポケモンを つかまえていません",
+		"Nature: %s":                          "せいかく: %s",
+		"Ability: %s":                         "とくせい: %s",
+		"Friendship: %d":                      "なつき度: %d",
+		"Caught: %s":                          "つかまえた日: %s",
+		"Caught: %s in %s":                    "つかまえた日: %s (%s)",
+		"Caught: %s with %s":                  "つかまえた日: %s、%s で",
+		"Caught: %s in %s with %s":            "つかまえた日: %s (%s)、%s で",
+		"#%d is called %s again":              "#%d は また %s と よばれます",
+		"%s #%d is now called %s":             "%s #%d は %s に なった",
+		"%s was released. Bye, %s!":           "%s を にがした。バイバイ、%s!",
+
+		"Your bag is empty, explore to find balls": "バッグは からっぽです。たんけんして ボールを さがしましょう",
+		"a plain ball":                           "ふつうの ボール",
+		"a good ball, x1.5":                      "よい ボール、x1.5",
+		"a better ball, x2":                      "もっと よい ボール、x2",
+		"never fails":                            "かならず つかまえられる",
+		"a commemorative Poke Ball":              "記念の モンスターボール",
+		"a comfortable Poke Ball":                "いごこちの よい モンスターボール",
+		"x3 on Water and Bug types":              "みず・むしタイプに x3",
+		"better the lower the level, up to x3.9": "レベルが ひくいほど よい、さいだい x3.9",
+		"x3 on species already caught":           "つかまえたことのある しゅぞくに x3",
+		"better the more throws, up to x4":       "なげるほど よい、さいだい x4",
+		"x4 on the first throw":                  "さいしょの 1かいに x4",
+		"x3.5 at night and in caves":             "よるや どうくつで x3.5",
+		"x3.5 when surfing or fishing":           "なみのり・つり中に x3.5",
+		"evolves some pokemon, see evolution":    "しんかに つかう、evolution を みてください",
+
+		"Wild %s":       "やせいの %s",
+		"%s's %s":       "%s の %s",
+		"Moves: %s":     "わざ: %s",
+		"Team: %s":      "てもち: %s",
+		"Weak to: %s":   "よわい: %s",
+		"Resists: %s":   "たいせい: %s",
+		"Immune to: %s": "こうかなし: %s",
+		"none":          "なし",
+		"Attacking: %s": "こうげき: %s",
+		"Defending: %s": "ぼうぎょ: %s",
+		"Moves:":        "わざ:",
+		"baby":          "ベビィ",
+		"You used %s.":  "%s を つかった。",
+		"Congratulations! Your %s evolved into %s!": "おめでとう! %s は %s に しんかした!",
+
+		"%s learns no moves in %s":    "%[2]s で %[1]s が おぼえる わざは ありません",
+		"%s learns no %s moves in %s": "%[3]s で %[1]s が おぼえる %[2]s わざは ありません",
+		"Moves %s learns in %s:":      "%[2]s で %[1]s が おぼえる わざ:",
+		"Type: %s":                    "タイプ: %s",
+		"Class: %s":                   "ぶんるい: %s",
+		"Power: %s":                   "いりょく: %s",
+		"Accuracy: %s":                "めいちゅう: %s",
+		"PP: %d":                      "PP: %d",
+		"Priority: %+d":               "ゆうせんど: %+d",
+		"Pokemon:":                    "ポケモン:",
+
+		"Cache cleared":       "キャッシュを けしました",
+		"Pokedex saved to %s": "ずかんを %s に セーブしました",
+		"Loaded %d pokemon from %s, which is saved from now on": "%[2]s から ポケモン %[1]d ひきを よみこみました。これからは このファイルに セーブします",
+		"Pokedex cleared": "ずかんを けしました",
+
+		"Cancelled":                 "キャンセルしました",
+		"Pokemon":                   "ポケモン",
+		"location area":             "エリア",
+		"Pokemon species":           "ポケモンの しゅぞく",
+		"PokeAPI has no %s listing": "PokeAPI に %s の いちらんは ありません",
+		"no %s named '%s'":          "'%[2]s' という %[1]s は ありません",
+		"no %s named '%s' — did you mean '%s'?":                              "'%[2]s' という %[1]s は ありません — '%[3]s' の ことですか?",
+		"PokeAPI is rate limiting us, wait a moment and try again":           "PokeAPI が リクエストを せいげんしています。すこし まってから もういちど ためしてください",
+		"PokeAPI is having trouble (%d %s), try again later":                 "PokeAPI で もんだいが おきています (%d %s)。あとで ためしてください",
+		"PokeAPI rejected the request for %s (%d %s)":                        "PokeAPI が %s への リクエストを こばみました (%d %s)",
+		"could not understand PokeAPI's answer for %s: %v":                   "%s への PokeAPI の こたえが よめません: %v",
+		"PokeAPI did not answer in time, try again or raise --timeout":       "PokeAPI から じかんないに こたえが ありません。もういちど ためすか --timeout を ふやしてください",
+		"could not reach PokeAPI: %v (use --offline to work from the cache)": "PokeAPI に つながりません: %v (キャッシュから つかうには --offline)",
+
+		"A wild %s (Lv. %d) wants to battle!": "やせいの %s (Lv. %d) が しょうぶを しかけてきた!",
+		"%s wants to battle!":                 "%s が しょうぶを しかけてきた!",
+		"%s sent out %s (Lv. %d)!":            "%s は %s (Lv. %d) を くりだした!",
+		"Go! %s!":                             "ゆけっ! %s!",
+		"Got away safely!":                    "うまく にげきれた!",
+		"Come back, %s! Go! %s!":              "もどれ %s! ゆけっ! %s!",
+		"the wild %s":                         "やせいの %s",
+		"The wild %s":                         "やせいの %s",
+		"the foe's %s":                        "あいての %s",
+		"The foe's %s":                        "あいての %s",
+		"%s used %s!":                         "%s の %s!",
+		"%s's attack missed!":                 "%s の こうげきは はずれた!",
+		"But nothing happened!":               "しかし なにも おこらない!",
+		"But it failed!":                      "しかし うまく きまらなかった!",
+		"It doesn't affect %s...":             "%s には こうかが ないようだ...",
+		"A critical hit!":                     "きゅうしょに あたった!",
+		"It's super effective!":               "こうかは ばつぐんだ!",
+		"It's not very effective...":          "こうかは いまひとつの ようだ...",
+		"%s lost %d HP.":                      "%s は %d HP を うしなった。",
+		"%s fainted!":                         "%s は たおれた!",
+		"%s won't go any lower!":              "%s は もう さがらない!",
+		"%s won't go any higher!":             "%s は もう あがらない!",
+		"%s harshly fell!":                    "%s が がくっと さがった!",
+		"%s fell!":                            "%s が さがった!",
+		"%s sharply rose!":                    "%s が ぐーんと あがった!",
+		"%s rose!":                            "%s が あがった!",
+		"You defeated %s!":                    "%s との しょうぶに かった!",
+		"You have no more pokemon that can fight! You blacked out!": "たたかえる ポケモンが いない! めのまえが まっくらに なった!",
+		"Youngster": "たんパンこぞう",
+		"Fisherman": "つりびと",
+		"Swimmer":   "かいパンやろう",
+
+		"level %d":                        "レベル %d",
+		"level up":                        "レベルアップ",
+		"use %s":                          "%s を つかう",
+		"trade":                           "つうしんこうかん",
+		"at level %d":                     "レベル %d で",
+		"using %s":                        "%s を つかって",
+		"holding %s":                      "%s を もたせて",
+		"for %s":                          "%s と",
+		"with friendship %d":              "なつき度 %d で",
+		"with affection %d":               "なかよし度 %d で",
+		"with beauty %d":                  "うつくしさ %d で",
+		"knowing %s":                      "%s を おぼえて",
+		"knowing a %s move":               "%s タイプの わざを おぼえて",
+		"if %s":                           "%s なら",
+		"at day":                          "ひるに",
+		"at night":                        "よるに",
+		"at %s":                           "%s で",
+		"in the rain":                     "あめの ときに",
+		"with %s in the party":            "てもちに %s が いるとき",
+		"with a %s type in the party":     "てもちに %s タイプが いるとき",
+		"if attack %s defense":            "こうげき %s ぼうぎょ なら",
+		"holding the console upside down": "ゲームきを さかさまに して",
+		", or ":                           "、または ",
+
+		"Welcome to the Pokedex!":      "ずかんへ ようこそ!",
+		"Usage:":                       "つかいかた:",
+		"Every command also accepts:":  "どの コマンドでも つかえる オプション:",
+		"Memory entries: %d":           "メモリの エントリ: %d",
+		"Memory hits: %d":              "メモリの ヒット: %d",
+		"Disk hits: %d":                "ディスクの ヒット: %d",
+		"Network fetches: %d":          "ネットワークからの しゅとく: %d",
+		"Disk cache: disabled":         "ディスクキャッシュ: むこう",
+		"Disk entries: %d":             "ディスクの エントリ: %d",
+		"Disk size: %.1f / %.1f MiB":   "ディスクの サイズ: %.1f / %.1f MiB",
+		"Disk evictions: %d":           "ディスクから すてた エントリ: %d",
+		"Prefetching %s %d/%d":         "%s を よみこみ中 %d/%d",
+		"Prefetched %s":                "さきに よみこみました: %s",
+		"Could not fetch %d resources": "%d この リソースを よみこめませんでした",
+		"Warning: the disk cache is full and dropped %d responses, raise --cache-size (now %d MiB) to keep everything offline": "けいこく: ディスクキャッシュが いっぱいで %d この こたえを すてました。すべて オフラインで つかうには --cache-size (いまは %d MiB) を ふやしてください",
+		"location areas":               "エリア",
+		"pokemon":                      "ポケモン",
+		"species and evolution chains": "しゅぞくと しんかの ながれ",
+		"moves":                        "わざ",
+		"abilities":                    "とくせい",
+		"types":                        "タイプ",
+		"stats":                        "のうりょく",
+
+		"usage: %s":                               "つかいかた: %s",
+		"unknown option --%s":                     "--%s という オプションは ありません",
+		"option --%s does not take a value":       "オプション --%s に あたいは いりません",
+		"option --%s needs a value":               "オプション --%s には あたいが ひつようです",
+		"%s needs %d argument(s), got %d":         "%s には ひきすうが %d こ ひつようですが %d こでした",
+		"%s takes at most %d argument(s), got %d": "%s の ひきすうは %d こまでですが %d こでした",
+		"unknown cache action %s":                 "%s という キャッシュの そうさは ありません",
+		"unknown target %s":                       "%s という たいしょうは ありません",
+		"--hp must be a percentage from 1 to 100": "--hp は 1 から 100 の パーセントで してい してください",
+		"a team has at most %d pokemon":           "てもちは %d ひきまでです",
+		"unknown learn method %s, want one of %s": "%s という おぼえかたは ありません。%s の どれかを してい してください",
+		"%s has no moves in %s, only in %s":       "%[2]s には %[1]s の わざは ありません。%[3]s だけです",
+		"%s does not evolve into %s, only %s":     "%s は %s に しんかしません。%s だけです",
+		"set %s takes no value":                   "set %s に あたいは いりません",
+		"set needs a name and a value":            "set には なまえと あたいが ひつようです",
+	},
+	"fr": {
+		"Pokedex > ":                "Pokédex > ",
+		"you're on the first page":  "vous êtes sur la première page",
+		"you're on the last page":   "vous êtes sur la dernière page",
+		"Exploring %s (%s)...":      "Exploration de %s (%s)...",
+		"Found Pokemon:":            "Pokémon trouvés :",
+		"Encounter rates: %s":       "Taux de rencontre : %s",
+		"Looking for pokemon by %s": "Recherche de pokémon par %s",
+		"You found %d %s!":          "Vous avez trouvé %d %s !",
+		"Name: %s":                  "Nom : %s",
+		"Genus: %s":                 "Catégorie : %s",
+		"Height: %s":                "Taille : %s",
+		"Weight: %s":                "Poids : %s",
+		"Generation: %s":            "Génération : %s",
+		"Habitat: %s":               "Habitat : %s",
+		"Color: %s":                 "Couleur : %s",
+		"Egg groups: %s":            "Groupes d'œufs : %s",
+		"Gender: %s":                "Sexe : %s",
+		"Capture rate: %d":          "Taux de capture : %d",
+		"Pokedex (%s): %s":          "Pokédex (%s) : %s",
+		"Stats:":                    "Statistiques :",
+		"Types:":                    "Types :",
+		"Abilities:":                "Talents :",
+		"hidden":                    "caché",
+		"genderless":                "asexué",
+		"%s male, %s female":        "%s mâle, %s femelle",
+		"Your Pokedex is empty":     "Votre Pokédex est vide",
+
+		"A wild %s (Lv. %d) appeared!":          "Un %s sauvage (Lv. %d) apparaît !",
+		"%s has a %.1f%% chance to be caught":   "%s a %.1f%% de chances d'être capturé",
+		"Throwing %s at %s...":                  "Vous lancez %s sur %s...",
+		"The ball shook %d time(s)...":          "La ball a bougé %d fois...",
+		"%s was caught! It is #%d in your list": "%s est capturé ! C'est le #%d de votre liste",
+		"%s escaped!":                           "%s s'est échappé !",
+		"%s left: %d":                           "%s restantes : %d",
+
+		"male":                                "mâle",
+		"female":                              "femelle",
+		"shiny":                               "chromatique",
+		"You have not caught any pokemon yet": "Vous n'avez encore capturé aucun pokémon",
+		"Nature: %s":                          "Nature : %s",
+		"Ability: %s":                         "Talent : %s",
+		"Friendship: %d":                      "Amitié : %d",
+		"Caught: %s":                          "Capturé : %s",
+		"Caught: %s in %s":                    "Capturé : %s à %s",
+		"Caught: %s with %s":                  "Capturé : %s avec %s",
+		"Caught: %s in %s with %s":            "Capturé : %s à %s avec %s",
+		"#%d is called %s again":              "#%d s'appelle de nouveau %s",
+		"%s #%d is now called %s":             "%s #%d s'appelle maintenant %s",
+		"%s was released. Bye, %s!":           "%s a été relâché. Au revoir, %s !",
+
+		"Your bag is empty, explore to find balls": "Votre sac est vide, explorez pour trouver des balls",
+		"a plain ball":                           "une ball ordinaire",
+		"a good ball, x1.5":                      "une bonne ball, x1.5",
+		"a better ball, x2":                      "une meilleure ball, x2",
+		"never fails":                            "ne rate jamais",
+		"a commemorative Poke Ball":              "une Poké Ball commémorative",
+		"a comfortable Poke Ball":                "une Poké Ball confortable",
+		"x3 on Water and Bug types":              "x3 sur les types Eau et Insecte",
+		"better the lower the level, up to x3.9": "meilleure quand le niveau est bas, jusqu'à x3.9",
+		"x3 on species already caught":           "x3 sur les espèces déjà capturées",
+		"better the more throws, up to x4":       "meilleure à chaque lancer, jusqu'à x4",
+		"x4 on the first throw":                  "x4 au premier lancer",
+		"x3.5 at night and in caves":             "x3.5 la nuit et dans les grottes",
+		"x3.5 when surfing or fishing":           "x3.5 en surfant ou en pêchant",
+		"evolves some pokemon, see evolution":    "fait évoluer certains pokémon, voir evolution",
+
+		"Wild %s":       "%s sauvage",
+		"%s's %s":       "%[2]s de %[1]s",
+		"Moves: %s":     "Capacités : %s",
+		"Team: %s":      "Équipe : %s",
+		"Weak to: %s":   "Faible contre : %s",
+		"Resists: %s":   "Résiste à : %s",
+		"Immune to: %s": "Immunisé contre : %s",
+		"none":          "aucun",
+		"Attacking: %s": "En attaque : %s",
+		"Defending: %s": "En défense : %s",
+		"Moves:":        "Capacités :",
+		"baby":          "bébé",
+		"You used %s.":  "Vous utilisez %s.",
+		"Congratulations! Your %s evolved into %s!": "Félicitations ! Votre %s a évolué en %s !",
+
+		"%s learns no moves in %s":    "%s n'apprend aucune capacité dans %s",
+		"%s learns no %s moves in %s": "%s n'apprend aucune capacité %s dans %s",
+		"Moves %s learns in %s:":      "Capacités que %s apprend dans %s :",
+		"Type: %s":                    "Type : %s",
+		"Class: %s":                   "Catégorie : %s",
+		"Power: %s":                   "Puissance : %s",
+		"Accuracy: %s":                "Précision : %s",
+		"PP: %d":                      "PP : %d",
+		"Priority: %+d":               "Priorité : %+d",
+		"Pokemon:":                    "Pokémon :",
+
+		"Cache cleared":       "Cache vidé",
+		"Pokedex saved to %s": "Pokédex enregistré dans %s",
+		"Loaded %d pokemon from %s, which is saved from now on": "%d pokémon chargés depuis %s, qui sera enregistré désormais",
+		"Pokedex cleared": "Pokédex effacé",
+
+		"Cancelled":                 "Annulé",
+		"Pokemon":                   "Pokémon",
+		"location area":             "zone",
+		"Pokemon species":           "espèce de Pokémon",
+		"PokeAPI has no %s listing": "PokeAPI n'a pas de liste de %s",
+		"no %s named '%s'":          "aucun %s nommé '%s'",
+		"no %s named '%s' — did you mean '%s'?":                              "aucun %s nommé '%s' — vouliez-vous dire '%s' ?",
+		"PokeAPI is rate limiting us, wait a moment and try again":           "PokeAPI limite nos requêtes, patientez un moment et réessayez",
+		"PokeAPI is having trouble (%d %s), try again later":                 "PokeAPI rencontre un problème (%d %s), réessayez plus tard",
+		"PokeAPI rejected the request for %s (%d %s)":                        "PokeAPI a refusé la requête pour %s (%d %s)",
+		"could not understand PokeAPI's answer for %s: %v":                   "réponse de PokeAPI incompréhensible pour %s : %v",
+		"PokeAPI did not answer in time, try again or raise --timeout":       "PokeAPI n'a pas répondu à temps, réessayez ou augmentez --timeout",
+		"could not reach PokeAPI: %v (use --offline to work from the cache)": "impossible de joindre PokeAPI : %v (utilisez --offline pour travailler depuis le cache)",
+
+		"A wild %s (Lv. %d) wants to battle!": "Un %s sauvage (Lv. %d) veut se battre !",
+		"%s wants to battle!":                 "%s veut se battre !",
+		"%s sent out %s (Lv. %d)!":            "%s envoie %s (Lv. %d) !",
+		"Go! %s!":                             "%s, go !",
+		"Got away safely!":                    "Vous prenez la fuite !",
+		"Come back, %s! Go! %s!":              "Reviens, %s ! %s, go !",
+		"the wild %s":                         "%s sauvage",
+		"The wild %s":                         "%s sauvage",
+		"the foe's %s":                        "%s adverse",
+		"The foe's %s":                        "%s adverse",
+		"%s used %s!":                         "%s utilise %s !",
+		"%s's attack missed!":                 "%s rate son attaque !",
+		"But nothing happened!":               "Mais rien ne se passe !",
+		"But it failed!":                      "Mais cela échoue !",
+		"It doesn't affect %s...":             "Ça n'affecte pas %s...",
+		"A critical hit!":                     "Coup critique !",
+		"It's super effective!":               "C'est super efficace !",
+		"It's not very effective...":          "Ce n'est pas très efficace...",
+		"%s lost %d HP.":                      "%s perd %d PV.",
+		"%s fainted!":                         "%s est K.O. !",
+		"%s won't go any lower!":              "%s ne peut plus baisser !",
+		"%s won't go any higher!":             "%s ne peut plus monter !",
+		"%s harshly fell!":                    "%s baisse beaucoup !",
+		"%s fell!":                            "%s baisse !",
+		"%s sharply rose!":                    "%s augmente beaucoup !",
+		"%s rose!":                            "%s augmente !",
+		"You defeated %s!":                    "Vous avez battu %s !",
+		"You have no more pokemon that can fight! You blacked out!": "Vous n'avez plus de pokémon en état de se battre ! Vous êtes hors jeu !",
+		"Youngster": "Gamin",
+		"Fisherman": "Pêcheur",
+		"Swimmer":   "Nageur",
+
+		"level %d":                        "niveau %d",
+		"level up":                        "montée de niveau",
+		"use %s":                          "utiliser %s",
+		"trade":                           "échange",
+		"at level %d":                     "au niveau %d",
+		"using %s":                        "avec %s",
+		"holding %s":                      "en tenant %s",
+		"for %s":                          "contre %s",
+		"with friendship %d":              "avec une amitié de %d",
+		"with affection %d":               "avec une affection de %d",
+		"with beauty %d":                  "avec une beauté de %d",
+		"knowing %s":                      "en connaissant %s",
+		"knowing a %s move":               "en connaissant une capacité %s",
+		"if %s":                           "si %s",
+		"at day":                          "de jour",
+		"at night":                        "de nuit",
+		"at %s":                           "à %s",
+		"in the rain":                     "sous la pluie",
+		"with %s in the party":            "avec %s dans l'équipe",
+		"with a %s type in the party":     "avec un type %s dans l'équipe",
+		"if attack %s defense":            "si attaque %s défense",
+		"holding the console upside down": "en tenant la console à l'envers",
+		", or ":                           ", ou ",
+
+		"Welcome to the Pokedex!":      "Bienvenue dans le Pokédex !",
+		"Usage:":                       "Utilisation :",
+		"Every command also accepts:":  "Toutes les commandes acceptent aussi :",
+		"Memory entries: %d":           "Entrées en mémoire : %d",
+		"Memory hits: %d":              "Succès en mémoire : %d",
+		"Disk hits: %d":                "Succès sur disque : %d",
+		"Network fetches: %d":          "Requêtes réseau : %d",
+		"Disk cache: disabled":         "Cache disque : désactivé",
+		"Disk entries: %d":             "Entrées sur disque : %d",
+		"Disk size: %.1f / %.1f MiB":   "Taille sur disque : %.1f / %.1f Mio",
+		"Disk evictions: %d":           "Entrées évincées du disque : %d",
+		"Prefetching %s %d/%d":         "Préchargement : %s %d/%d",
+		"Prefetched %s":                "Préchargé : %s",
+		"Could not fetch %d resources": "Impossible de récupérer %d ressources",
+		"Warning: the disk cache is full and dropped %d responses, raise --cache-size (now %d MiB) to keep everything offline": "Attention : le cache disque est plein et a abandonné %d réponses, augmentez --cache-size (actuellement %d Mio) pour tout garder hors ligne",
+		"location areas":               "zones",
+		"pokemon":                      "pokémon",
+		"species and evolution chains": "espèces et chaînes d'évolution",
+		"moves":                        "capacités",
+		"abilities":                    "talents",
+		"types":                        "types",
+		"stats":                        "statistiques",
+
+		"usage: %s":                               "utilisation : %s",
+		"unknown option --%s":                     "option --%s inconnue",
+		"option --%s does not take a value":       "l'option --%s ne prend pas de valeur",
+		"option --%s needs a value":               "l'option --%s a besoin d'une valeur",
+		"%s needs %d argument(s), got %d":         "%s a besoin de %d argument(s), reçu %d",
+		"%s takes at most %d argument(s), got %d": "%s prend au plus %d argument(s), reçu %d",
+		"unknown cache action %s":                 "action de cache %s inconnue",
+		"unknown target %s":                       "cible %s inconnue",
+		"--hp must be a percentage from 1 to 100": "--hp doit être un pourcentage de 1 à 100",
+		"a team has at most %d pokemon":           "une équipe compte au plus %d pokémon",
+		"unknown learn method %s, want one of %s": "méthode d'apprentissage %s inconnue, attendu : %s",
+		"%s has no moves in %s, only in %s":       "%s n'a pas de capacités dans %s, seulement dans %s",
+		"%s does not evolve into %s, only %s":     "%s n'évolue pas en %s, seulement en %s",
+		"set %s takes no value":                   "set %s ne prend pas de valeur",
+		"set needs a name and a value":            "set a besoin d'un nom et d'une valeur",
+	},
+	"de": {
+		"Pokedex > ":                "Pokédex > ",
+		"you're on the first page":  "du bist auf der ersten Seite",
+		"you're on the last page":   "du bist auf der letzten Seite",
+		"Exploring %s (%s)...":      "Erkunde %s (%s)...",
+		"Found Pokemon:":            "Gefundene Pokémon:",
+		"Encounter rates: %s":       "Begegnungsraten: %s",
+		"Looking for pokemon by %s": "Suche nach Pokémon per %s",
+		"You found %d %s!":          "Du hast %d %s gefunden!",
+		"Name: %s":                  "Name: %s",
+		"Genus: %s":                 "Kategorie: %s",
+		"Height: %s":                "Größe: %s",
+		"Weight: %s":                "Gewicht: %s",
+		"Generation: %s":            "Generation: %s",
+		"Habitat: %s":               "Lebensraum: %s",
+		"Color: %s":                 "Farbe: %s",
+		"Egg groups: %s":            "Ei-Gruppen: %s",
+		"Gender: %s":                "Geschlecht: %s",
+		"Capture rate: %d":          "Fangrate: %d",
+		"Pokedex (%s): %s":          "Pokédex (%s): %s",
+		"Stats:":                    "Werte:",
+		"Types:":                    "Typen:",
+		"Abilities:":                "Fähigkeiten:",
+		"hidden":                    "versteckt",
+		"genderless":                "geschlechtslos",
+		"%s male, %s female":        "%s männlich, %s weiblich",
+		"Your Pokedex is empty":     "Dein Pokédex ist leer",
+
+		"A wild %s (Lv. %d) appeared!":          "Ein wildes %s (Lv. %d) erscheint!",
+		"%s has a %.1f%% chance to be caught":   "%s wird mit %.1f%% Wahrscheinlichkeit gefangen",
+		"Throwing %s at %s...":                  "Du wirfst %s auf %s...",
+		"The ball shook %d time(s)...":          "Der Ball hat %d Mal gewackelt...",
+		"%s was caught! It is #%d in your list": "%s wurde gefangen! Es ist #%d in deiner Liste",
+		"%s escaped!":                           "%s ist entkommen!",
+		"%s left: %d":                           "%s übrig: %d",
+
+		"male":                                "männlich",
+		"female":                              "weiblich",
+		"shiny":                               "schillernd",
+		"You have not caught any pokemon yet": "Du hast noch kein Pokémon gefangen",
+		"Nature: %s":                          "Wesen: %s",
+		"Ability: %s":                         "Fähigkeit: %s",
+		"Friendship: %d":                      "Freundschaft: %d",
+		"Caught: %s":                          "Gefangen: %s",
+		"Caught: %s in %s":                    "Gefangen: %s in %s",
+		"Caught: %s with %s":                  "Gefangen: %s mit %s",
+		"Caught: %s in %s with %s":            "Gefangen: %s in %s mit %s",
+		"#%d is called %s again":              "#%d heißt wieder %s",
+		"%s #%d is now called %s":             "%s #%d heißt jetzt %s",
+		"%s was released. Bye, %s!":           "%s wurde freigelassen. Tschüss, %s!",
+
+		"Your bag is empty, explore to find balls": "Dein Beutel ist leer, erkunde Gebiete, um Bälle zu finden",
+		"a plain ball":                           "ein einfacher Ball",
+		"a good ball, x1.5":                      "ein guter Ball, x1.5",
+		"a better ball, x2":                      "ein besserer Ball, x2",
+		"never fails":                            "versagt nie",
+		"a commemorative Poke Ball":              "ein Gedenk-Pokéball",
+		"a comfortable Poke Ball":                "ein bequemer Pokéball",
+		"x3 on Water and Bug types":              "x3 bei Wasser- und Käfer-Typen",
+		"better the lower the level, up to x3.9": "besser je niedriger das Level, bis x3.9",
+		"x3 on species already caught":           "x3 bei schon gefangenen Arten",
+		"better the more throws, up to x4":       "besser mit jedem Wurf, bis x4",
+		"x4 on the first throw":                  "x4 beim ersten Wurf",
+		"x3.5 at night and in caves":             "x3.5 nachts und in Höhlen",
+		"x3.5 when surfing or fishing":           "x3.5 beim Surfen oder Angeln",
+		"evolves some pokemon, see evolution":    "entwickelt manche Pokémon, siehe evolution",
+
+		"Wild %s":       "Wildes %s",
+		"%s's %s":       "%[2]s von %[1]s",
+		"Moves: %s":     "Attacken: %s",
+		"Team: %s":      "Team: %s",
+		"Weak to: %s":   "Schwach gegen: %s",
+		"Resists: %s":   "Resistent gegen: %s",
+		"Immune to: %s": "Immun gegen: %s",
+		"none":          "keine",
+		"Attacking: %s": "Im Angriff: %s",
+		"Defending: %s": "In der Verteidigung: %s",
+		"Moves:":        "Attacken:",
+		"baby":          "Baby",
+		"You used %s.":  "Du hast %s benutzt.",
+		"Congratulations! Your %s evolved into %s!": "Glückwunsch! Dein %s hat sich zu %s entwickelt!",
+
+		"%s learns no moves in %s":    "%[1]s lernt in %[2]s keine Attacken",
+		"%s learns no %s moves in %s": "%[1]s lernt in %[3]s keine %[2]s-Attacken",
+		"Moves %s learns in %s:":      "Attacken, die %s in %s lernt:",
+		"Type: %s":                    "Typ: %s",
+		"Class: %s":                   "Kategorie: %s",
+		"Power: %s":                   "Stärke: %s",
+		"Accuracy: %s":                "Genauigkeit: %s",
+		"PP: %d":                      "AP: %d",
+		"Priority: %+d":               "Priorität: %+d",
+		"Pokemon:":                    "Pokémon:",
+
+		"Cache cleared":       "Cache geleert",
+		"Pokedex saved to %s": "Pokédex in %s gespeichert",
+		"Loaded %d pokemon from %s, which is saved from now on": "%d Pokémon aus %s geladen, ab jetzt wird dorthin gespeichert",
+		"Pokedex cleared": "Pokédex gelöscht",
+
+		"Cancelled":                 "Abgebrochen",
+		"Pokemon":                   "Pokémon",
+		"location area":             "Gebiet",
+		"Pokemon species":           "Pokémon-Art",
+		"PokeAPI has no %s listing": "PokeAPI hat keine Liste für %s",
+		"no %s named '%s'":          "kein %s namens '%s'",
+		"no %s named '%s' — did you mean '%s'?":                              "kein %s namens '%s' — meintest du '%s'?",
+		"PokeAPI is rate limiting us, wait a moment and try again":           "PokeAPI drosselt die Anfragen, warte kurz und versuche es erneut",
+		"PokeAPI is having trouble (%d %s), try again later":                 "PokeAPI hat Probleme (%d %s), versuche es später erneut",
+		"PokeAPI rejected the request for %s (%d %s)":                        "PokeAPI hat die Anfrage für %s abgelehnt (%d %s)",
+		"could not understand PokeAPI's answer for %s: %v":                   "die Antwort von PokeAPI für %s ist unverständlich: %v",
+		"PokeAPI did not answer in time, try again or raise --timeout":       "PokeAPI hat nicht rechtzeitig geantwortet, versuche es erneut oder erhöhe --timeout",
+		"could not reach PokeAPI: %v (use --offline to work from the cache)": "PokeAPI nicht erreichbar: %v (mit --offline aus dem Cache arbeiten)",
+
+		"A wild %s (Lv. %d) wants to battle!": "Ein wildes %s (Lv. %d) will kämpfen!",
+		"%s wants to battle!":                 "%s will kämpfen!",
+		"%s sent out %s (Lv. %d)!":            "%s schickt %s (Lv. %d) in den Kampf!",
+		"Go! %s!":                             "Los, %s!",
+		"Got away safely!":                    "Du bist entkommen!",
+		"Come back, %s! Go! %s!":              "Komm zurück, %s! Los, %s!",
+		"the wild %s":                         "wildes %s",
+		"The wild %s":                         "Wildes %s",
+		"the foe's %s":                        "gegnerisches %s",
+		"The foe's %s":                        "Gegnerisches %s",
+		"%s used %s!":                         "%s setzt %s ein!",
+		"%s's attack missed!":                 "Die Attacke von %s hat verfehlt!",
+		"But nothing happened!":               "Aber nichts passiert!",
+		"But it failed!":                      "Aber es schlägt fehl!",
+		"It doesn't affect %s...":             "Es hat keine Wirkung auf %s...",
+		"A critical hit!":                     "Ein Volltreffer!",
+		"It's super effective!":               "Das ist sehr effektiv!",
+		"It's not very effective...":          "Das ist nicht sehr effektiv...",
+		"%s lost %d HP.":                      "%s verliert %d KP.",
+		"%s fainted!":                         "%s wurde besiegt!",
+		"%s won't go any lower!":              "%s kann nicht weiter sinken!",
+		"%s won't go any higher!":             "%s kann nicht weiter steigen!",
+		"%s harshly fell!":                    "%s sinkt stark!",
+		"%s fell!":                            "%s sinkt!",
+		"%s sharply rose!":                    "%s steigt stark!",
+		"%s rose!":                            "%s steigt!",
+		"You defeated %s!":                    "Du hast %s besiegt!",
+		"You have no more pokemon that can fight! You blacked out!": "Du hast keine kampffähigen Pokémon mehr! Dir wird schwarz vor Augen!",
+		"Youngster": "Knirps",
+		"Fisherman": "Angler",
+		"Swimmer":   "Schwimmer",
+
+		"level %d":                        "Level %d",
+		"level up":                        "Levelaufstieg",
+		"use %s":                          "%s benutzen",
+		"trade":                           "Tausch",
+		"at level %d":                     "ab Level %d",
+		"using %s":                        "mit %s",
+		"holding %s":                      "mit %s als Item",
+		"for %s":                          "gegen %s",
+		"with friendship %d":              "mit Freundschaft %d",
+		"with affection %d":               "mit Zuneigung %d",
+		"with beauty %d":                  "mit Schönheit %d",
+		"knowing %s":                      "mit der Attacke %s",
+		"knowing a %s move":               "mit einer %s-Attacke",
+		"if %s":                           "wenn %s",
+		"at day":                          "tagsüber",
+		"at night":                        "nachts",
+		"at %s":                           "in %s",
+		"in the rain":                     "bei Regen",
+		"with %s in the party":            "mit %s im Team",
+		"with a %s type in the party":     "mit einem %s-Pokémon im Team",
+		"if attack %s defense":            "wenn Angriff %s Verteidigung",
+		"holding the console upside down": "mit der Konsole auf dem Kopf",
+		", or ":                           ", oder ",
+
+		"Welcome to the Pokedex!":      "Willkommen im Pokédex!",
+		"Usage:":                       "Verwendung:",
+		"Every command also accepts:":  "Jeder Befehl akzeptiert außerdem:",
+		"Memory entries: %d":           "Einträge im Speicher: %d",
+		"Memory hits: %d":              "Treffer im Speicher: %d",
+		"Disk hits: %d":                "Treffer auf der Festplatte: %d",
+		"Network fetches: %d":          "Abrufe aus dem Netz: %d",
+		"Disk cache: disabled":         "Festplatten-Cache: deaktiviert",
+		"Disk entries: %d":             "Einträge auf der Festplatte: %d",
+		"Disk size: %.1f / %.1f MiB":   "Größe auf der Festplatte: %.1f / %.1f MiB",
+		"Disk evictions: %d":           "Von der Festplatte verdrängt: %d",
+		"Prefetching %s %d/%d":         "Lade %s vorab %d/%d",
+		"Prefetched %s":                "Vorab geladen: %s",
+		"Could not fetch %d resources": "%d Ressourcen konnten nicht geladen werden",
+		"Warning: the disk cache is full and dropped %d responses, raise --cache-size (now %d MiB) to keep everything offline": "Warnung: Der Festplatten-Cache ist voll und hat %d Antworten verworfen, erhöhe --cache-size (jetzt %d MiB), um alles offline zu behalten",
+		"location areas":               "Gebiete",
+		"pokemon":                      "Pokémon",
+		"species and evolution chains": "Arten und Entwicklungsketten",
+		"moves":                        "Attacken",
+		"abilities":                    "Fähigkeiten",
+		"types":                        "Typen",
+		"stats":                        "Werte",
+
+		"usage: %s":                               "Verwendung: %s",
+		"unknown option --%s":                     "unbekannte Option --%s",
+		"option --%s does not take a value":       "Option --%s nimmt keinen Wert",
+		"option --%s needs a value":               "Option --%s braucht einen Wert",
+		"%s needs %d argument(s), got %d":         "%s braucht %d Argument(e), erhalten: %d",
+		"%s takes at most %d argument(s), got %d": "%s nimmt höchstens %d Argument(e), erhalten: %d",
+		"unknown cache action %s":                 "unbekannte Cache-Aktion %s",
+		"unknown target %s":                       "unbekanntes Ziel %s",
+		"--hp must be a percentage from 1 to 100": "--hp muss ein Prozentwert von 1 bis 100 sein",
+		"a team has at most %d pokemon":           "ein Team hat höchstens %d Pokémon",
+		"unknown learn method %s, want one of %s": "unbekannte Lernmethode %s, erwartet: %s",
+		"%s has no moves in %s, only in %s":       "%[1]s hat in %[2]s keine Attacken, nur in %[3]s",
+		"%s does not evolve into %s, only %s":     "%s entwickelt sich nicht zu %s, nur zu %s",
+		"set %s takes no value":                   "set %s nimmt keinen Wert",
+		"set needs a name and a value":            "set braucht einen Namen und einen Wert",
+	},
+	"es": {
+		"Pokedex > ":                "Pokédex > ",
+		"you're on the first page":  "estás en la primera página",
+		"you're on the last page":   "estás en la última página",
+		"Exploring %s (%s)...":      "Explorando %s (%s)...",
+		"Found Pokemon:":            "Pokémon encontrados:",
+		"Encounter rates: %s":       "Tasas de encuentro: %s",
+		"Looking for pokemon by %s": "Buscando pokémon con %s",
+		"You found %d %s!":          "¡Has encontrado %d %s!",
+		"Name: %s":                  "Nombre: %s",
+		"Genus: %s":                 "Categoría: %s",
+		"Height: %s":                "Altura: %s",
+		"Weight: %s":                "Peso: %s",
+		"Generation: %s":            "Generación: %s",
+		"Habitat: %s":               "Hábitat: %s",
+		"Color: %s":                 "Color: %s",
+		"Egg groups: %s":            "Grupos huevo: %s",
+		"Gender: %s":                "Sexo: %s",
+		"Capture rate: %d":          "Ratio de captura: %d",
+		"Pokedex (%s): %s":          "Pokédex (%s): %s",
+		"Stats:":                    "Estadísticas:",
+		"Types:":                    "Tipos:",
+		"Abilities:":                "Habilidades:",
+		"hidden":                    "oculta",
+		"genderless":                "sin sexo",
+		"%s male, %s female":        "%s macho, %s hembra",
+		"Your Pokedex is empty":     "Tu Pokédex está vacía",
+
+		"A wild %s (Lv. %d) appeared!":          "¡Un %s salvaje (Lv. %d) apareció!",
+		"%s has a %.1f%% chance to be caught":   "%s tiene un %.1f%% de probabilidad de ser capturado",
+		"Throwing %s at %s...":                  "Lanzas %s a %s...",
+		"The ball shook %d time(s)...":          "La ball se sacudió %d vez/veces...",
+		"%s was caught! It is #%d in your list": "¡%s fue capturado! Es el #%d de tu lista",
+		"%s escaped!":                           "¡%s escapó!",
+		"%s left: %d":                           "%s restantes: %d",
+
+		"male":                                "macho",
+		"female":                              "hembra",
+		"shiny":                               "variocolor",
+		"You have not caught any pokemon yet": "Todavía no has capturado ningún pokémon",
+		"Nature: %s":                          "Naturaleza: %s",
+		"Ability: %s":                         "Habilidad: %s",
+		"Friendship: %d":                      "Amistad: %d",
+		"Caught: %s":                          "Capturado: %s",
+		"Caught: %s in %s":                    "Capturado: %s en %s",
+		"Caught: %s with %s":                  "Capturado: %s con %s",
+		"Caught: %s in %s with %s":            "Capturado: %s en %s con %s",
+		"#%d is called %s again":              "#%d vuelve a llamarse %s",
+		"%s #%d is now called %s":             "%s #%d ahora se llama %s",
+		"%s was released. Bye, %s!":           "%s fue liberado. ¡Adiós, %s!",
+
+		"Your bag is empty, explore to find balls": "Tu mochila está vacía, explora para encontrar balls",
+		"a plain ball":                           "una ball corriente",
+		"a good ball, x1.5":                      "una buena ball, x1.5",
+		"a better ball, x2":                      "una ball mejor, x2",
+		"never fails":                            "nunca falla",
+		"a commemorative Poke Ball":              "una Poké Ball conmemorativa",
+		"a comfortable Poke Ball":                "una Poké Ball cómoda",
+		"x3 on Water and Bug types":              "x3 con los tipos Agua y Bicho",
+		"better the lower the level, up to x3.9": "mejor cuanto menor el nivel, hasta x3.9",
+		"x3 on species already caught":           "x3 con especies ya capturadas",
+		"better the more throws, up to x4":       "mejor cuantos más lanzamientos, hasta x4",
+		"x4 on the first throw":                  "x4 en el primer lanzamiento",
+		"x3.5 at night and in caves":             "x3.5 de noche y en cuevas",
+		"x3.5 when surfing or fishing":           "x3.5 surfeando o pescando",
+		"evolves some pokemon, see evolution":    "hace evolucionar a algunos pokémon, ver evolution",
+
+		"Wild %s":       "%s salvaje",
+		"%s's %s":       "%[2]s de %[1]s",
+		"Moves: %s":     "Movimientos: %s",
+		"Team: %s":      "Equipo: %s",
+		"Weak to: %s":   "Débil contra: %s",
+		"Resists: %s":   "Resiste: %s",
+		"Immune to: %s": "Inmune a: %s",
+		"none":          "ninguno",
+		"Attacking: %s": "Atacando: %s",
+		"Defending: %s": "Defendiendo: %s",
+		"Moves:":        "Movimientos:",
+		"baby":          "bebé",
+		"You used %s.":  "Usaste %s.",
+		"Congratulations! Your %s evolved into %s!": "¡Enhorabuena! ¡Tu %s evolucionó a %s!",
+
+		"%s learns no moves in %s":    "%s no aprende movimientos en %s",
+		"%s learns no %s moves in %s": "%s no aprende movimientos %s en %s",
+		"Moves %s learns in %s:":      "Movimientos que %s aprende en %s:",
+		"Type: %s":                    "Tipo: %s",
+		"Class: %s":                   "Categoría: %s",
+		"Power: %s":                   "Potencia: %s",
+		"Accuracy: %s":                "Precisión: %s",
+		"PP: %d":                      "PP: %d",
+		"Priority: %+d":               "Prioridad: %+d",
+		"Pokemon:":                    "Pokémon:",
+
+		"Cache cleared":       "Caché vaciada",
+		"Pokedex saved to %s": "Pokédex guardado en %s",
+		"Loaded %d pokemon from %s, which is saved from now on": "%d pokémon cargados desde %s, que se guardará a partir de ahora",
+		"Pokedex cleared": "Pokédex borrado",
+
+		"Cancelled":                 "Cancelado",
+		"Pokemon":                   "Pokémon",
+		"location area":             "zona",
+		"Pokemon species":           "especie de Pokémon",
+		"PokeAPI has no %s listing": "PokeAPI no tiene listado de %s",
+		"no %s named '%s'":          "ningún %s llamado '%s'",
+		"no %s named '%s' — did you mean '%s'?":                              "ningún %s llamado '%s' — ¿quisiste decir '%s'?",
+		"PokeAPI is rate limiting us, wait a moment and try again":           "PokeAPI está limitando las peticiones, espera un momento y vuelve a intentarlo",
+		"PokeAPI is having trouble (%d %s), try again later":                 "PokeAPI tiene problemas (%d %s), inténtalo más tarde",
+		"PokeAPI rejected the request for %s (%d %s)":                        "PokeAPI rechazó la petición de %s (%d %s)",
+		"could not understand PokeAPI's answer for %s: %v":                   "no se entiende la respuesta de PokeAPI para %s: %v",
+		"PokeAPI did not answer in time, try again or raise --timeout":       "PokeAPI no respondió a tiempo, vuelve a intentarlo o aumenta --timeout",
+		"could not reach PokeAPI: %v (use --offline to work from the cache)": "no se puede contactar con PokeAPI: %v (usa --offline para trabajar desde la caché)",
+
+		"A wild %s (Lv. %d) wants to battle!": "¡Un %s salvaje (Lv. %d) quiere luchar!",
+		"%s wants to battle!":                 "¡%s quiere luchar!",
+		"%s sent out %s (Lv. %d)!":            "¡%s sacó a %s (Lv. %d)!",
+		"Go! %s!":                             "¡Adelante, %s!",
+		"Got away safely!":                    "¡Escapaste sin problemas!",
+		"Come back, %s! Go! %s!":              "¡Vuelve, %s! ¡Adelante, %s!",
+		"the wild %s":                         "%s salvaje",
+		"The wild %s":                         "%s salvaje",
+		"the foe's %s":                        "%s rival",
+		"The foe's %s":                        "%s rival",
+		"%s used %s!":                         "¡%s usó %s!",
+		"%s's attack missed!":                 "¡El ataque de %s falló!",
+		"But nothing happened!":               "¡Pero no pasó nada!",
+		"But it failed!":                      "¡Pero falló!",
+		"It doesn't affect %s...":             "No afecta a %s...",
+		"A critical hit!":                     "¡Un golpe crítico!",
+		"It's super effective!":               "¡Es súper eficaz!",
+		"It's not very effective...":          "No es muy eficaz...",
+		"%s lost %d HP.":                      "%s perdió %d PS.",
+		"%s fainted!":                         "¡%s se debilitó!",
+		"%s won't go any lower!":              "¡%s no puede bajar más!",
+		"%s won't go any higher!":             "¡%s no puede subir más!",
+		"%s harshly fell!":                    "¡%s bajó mucho!",
+		"%s fell!":                            "¡%s bajó!",
+		"%s sharply rose!":                    "¡%s subió mucho!",
+		"%s rose!":                            "¡%s subió!",
+		"You defeated %s!":                    "¡Derrotaste a %s!",
+		"You have no more pokemon that can fight! You blacked out!": "¡No te quedan pokémon que puedan luchar! ¡Te has desmayado!",
+		"Youngster": "Joven",
+		"Fisherman": "Pescador",
+		"Swimmer":   "Nadador",
+
+		"level %d":                        "nivel %d",
+		"level up":                        "subir de nivel",
+		"use %s":                          "usar %s",
+		"trade":                           "intercambio",
+		"at level %d":                     "al nivel %d",
+		"using %s":                        "usando %s",
+		"holding %s":                      "equipado con %s",
+		"for %s":                          "por %s",
+		"with friendship %d":              "con amistad %d",
+		"with affection %d":               "con afecto %d",
+		"with beauty %d":                  "con belleza %d",
+		"knowing %s":                      "sabiendo %s",
+		"knowing a %s move":               "sabiendo un movimiento %s",
+		"if %s":                           "si es %s",
+		"at day":                          "de día",
+		"at night":                        "de noche",
+		"at %s":                           "en %s",
+		"in the rain":                     "con lluvia",
+		"with %s in the party":            "con %s en el equipo",
+		"with a %s type in the party":     "con un tipo %s en el equipo",
+		"if attack %s defense":            "si ataque %s defensa",
+		"holding the console upside down": "con la consola boca abajo",
+		", or ":                           ", o ",
+
+		"Welcome to the Pokedex!":      "¡Bienvenido a la Pokédex!",
+		"Usage:":                       "Uso:",
+		"Every command also accepts:":  "Todos los comandos aceptan también:",
+		"Memory entries: %d":           "Entradas en memoria: %d",
+		"Memory hits: %d":              "Aciertos en memoria: %d",
+		"Disk hits: %d":                "Aciertos en disco: %d",
+		"Network fetches: %d":          "Descargas de red: %d",
+		"Disk cache: disabled":         "Caché en disco: desactivada",
+		"Disk entries: %d":             "Entradas en disco: %d",
+		"Disk size: %.1f / %.1f MiB":   "Tamaño en disco: %.1f / %.1f MiB",
+		"Disk evictions: %d":           "Entradas desalojadas del disco: %d",
+		"Prefetching %s %d/%d":         "Precargando %s %d/%d",
+		"Prefetched %s":                "Precargado: %s",
+		"Could not fetch %d resources": "No se pudieron obtener %d recursos",
+		"Warning: the disk cache is full and dropped %d responses, raise --cache-size (now %d MiB) to keep everything offline": "Aviso: la caché en disco está llena y descartó %d respuestas, aumenta --cache-size (ahora %d MiB) para tenerlo todo sin conexión",
+		"location areas":               "zonas",
+		"pokemon":                      "pokémon",
+		"species and evolution chains": "especies y cadenas evolutivas",
+		"moves":                        "movimientos",
+		"abilities":                    "habilidades",
+		"types":                        "tipos",
+		"stats":                        "estadísticas",
+
+		"usage: %s":                               "uso: %s",
+		"unknown option --%s":                     "opción --%s desconocida",
+		"option --%s does not take a value":       "la opción --%s no lleva valor",
+		"option --%s needs a value":               "la opción --%s necesita un valor",
+		"%s needs %d argument(s), got %d":         "%s necesita %d argumento(s), recibió %d",
+		"%s takes at most %d argument(s), got %d": "%s admite como máximo %d argumento(s), recibió %d",
+		"unknown cache action %s":                 "acción de caché %s desconocida",
+		"unknown target %s":                       "objetivo %s desconocido",
+		"--hp must be a percentage from 1 to 100": "--hp debe ser un porcentaje de 1 a 100",
+		"a team has at most %d pokemon":           "un equipo tiene como máximo %d pokémon",
+		"unknown learn method %s, want one of %s": "método de aprendizaje %s desconocido, se espera uno de %s",
+		"%s has no moves in %s, only in %s":       "%s no tiene movimientos en %s, solo en %s",
+		"%s does not evolve into %s, only %s":     "%s no evoluciona a %s, solo a %s",
+		"set %s takes no value":                   "set %s no lleva valor",
+		"set needs a name and a value":            "set necesita un nombre y un valor",
+	},
+}
@@ -0,0 +1,45 @@
+package i18n
+
+import (
+	"regexp"
+	"testing"
+)
+
+var verb = regexp.MustCompile(`%(\[\d\])?[a-z]`)
+
+func TestCatalogsAreComplete(t *testing.T) {
+	for lang := range catalog {
+		if len(catalog[lang]) != len(catalog["ja"]) {
+			t.Errorf("%s has %d messages, ja has %d", lang, len(catalog[lang]), len(catalog["ja"]))
+		}
+		for msg, s := range catalog[lang] {
+			if _, ok := catalog["ja"][msg]; !ok {
+				t.Errorf("%s translates %q, which ja does not", lang, msg)
+			}
+			if len(verb.FindAllString(s, -1)) != len(verb.FindAllString(msg, -1)) {
+				t.Errorf("%s: %q does not take the arguments of %q", lang, s, msg)
+			}
+		}
+	}
+}
+
+func TestT(t *testing.T) {
+	if got := Sprintf("ja", "You found %d %s!", 2, "Poke Balls"); got != "Poke Balls を 2 こ 見つけた!" {
+		t.Errorf("Sprintf(ja) = %q", got)
+	}
+	if got := T("xx", "Stats:"); got != "Stats:" {
+		t.Errorf("T(xx) = %q, want the English message", got)
+	}
+	if !Translated("ja") || Translated("xx") || Translated(English) {
+		t.Error("Translated should only report the languages with a catalog")
+	}
+}
+
+func TestTitle(t *testing.T) {
+	titles := map[string]string{"thunder-stone": "Thunder Stone", "poke-ball": "Poke Ball", "": "", "poke--ball": "Poke Ball", "master-": "Master", "-": ""}
+	for slug, want := range titles {
+		if got := Title(slug); got != want {
+			t.Errorf("Title(%q) = %q, want %q", slug, got, want)
+		}
+	}
+}
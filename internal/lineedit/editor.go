@@ -0,0 +1,398 @@
+// Package lineedit is a small readline-style line editor with history,
+// reverse search and tab completion. When input is not a terminal it falls
+// back to reading plain lines.
+package lineedit
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ErrInterrupted is returned by ReadLine when the user presses Ctrl-C.
+var ErrInterrupted = errors.New("interrupted")
+
+// Completer returns candidates for the text being typed. line and pos are
+// the buffer and cursor position; the candidates replace line[start:pos].
+type Completer func(line string, pos int) (start int, candidates []string)
+
+// Editor reads lines from a terminal.
+type Editor struct {
+	History  *History
+	Complete Completer
+
+	in     *os.File
+	out    io.Writer
+	reader *bufio.Reader
+}
+
+// New returns an Editor reading from in and echoing to out.
+func New(in *os.File, out io.Writer) *Editor {
+	return &Editor{
+		History: NewHistory(0),
+		in:      in,
+		out:     out,
+		reader:  bufio.NewReader(in),
+	}
+}
+
+// ReadLine shows prompt and returns the entered line without its newline.
+// It returns io.EOF on Ctrl-D at an empty line and ErrInterrupted on Ctrl-C.
+func (e *Editor) ReadLine(prompt string) (string, error) {
+	fmt.Fprint(e.out, prompt)
+	if !isTerminal(e.in.Fd()) {
+		return e.readPlain()
+	}
+	restore, err := makeRaw(e.in.Fd())
+	if err != nil {
+		return e.readPlain()
+	}
+	defer restore()
+
+	s := &state{editor: e, prompt: prompt, histIndex: e.History.Len()}
+	line, err := s.run()
+	fmt.Fprint(e.out, "\r\n")
+	return line, err
+}
+
+func (e *Editor) readPlain() (string, error) {
+	line, err := e.reader.ReadString('\n')
+	if err != nil && (line == "" || !errors.Is(err, io.EOF)) {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+const (
+	keyCtrlA     = 1
+	keyCtrlB     = 2
+	keyCtrlC     = 3
+	keyCtrlD     = 4
+	keyCtrlE     = 5
+	keyCtrlF     = 6
+	keyCtrlG     = 7
+	keyCtrlH     = 8
+	keyTab       = 9
+	keyCtrlK     = 11
+	keyCtrlL     = 12
+	keyEnter     = 13
+	keyCtrlN     = 14
+	keyCtrlP     = 16
+	keyCtrlR     = 18
+	keyCtrlU     = 21
+	keyCtrlW     = 23
+	keyEscape    = 27
+	keyBackspace = 127
+)
+
+// Keys decoded from escape sequences, outside the rune range.
+const (
+	keyUp rune = -(iota + 1)
+	keyDown
+	keyRight
+	keyLeft
+	keyHome
+	keyEnd
+	keyDelete
+)
+
+type state struct {
+	editor *Editor
+	prompt string
+	buf    []rune
+	pos    int
+
+	histIndex int
+	saved     []rune
+
+	lastWasTab bool
+
+	searching   bool
+	query       []rune
+	searchIndex int
+}
+
+func (s *state) run() (string, error) {
+	for {
+		key, err := s.readKey()
+		if err != nil {
+			return "", err
+		}
+
+		if s.searching {
+			done, err := s.handleSearchKey(key)
+			if done || err != nil {
+				return string(s.buf), err
+			}
+			continue
+		}
+
+		tab := key == keyTab
+		switch key {
+		case keyEnter, '\n':
+			return string(s.buf), nil
+		case keyCtrlC:
+			return "", ErrInterrupted
+		case keyCtrlD:
+			if len(s.buf) == 0 {
+				return "", io.EOF
+			}
+			s.deleteAt(s.pos)
+		case keyTab:
+			s.complete()
+		case keyBackspace, keyCtrlH:
+			if s.pos > 0 {
+				s.pos--
+				s.deleteAt(s.pos)
+			}
+		case keyDelete:
+			s.deleteAt(s.pos)
+		case keyCtrlA, keyHome:
+			s.pos = 0
+		case keyCtrlE, keyEnd:
+			s.pos = len(s.buf)
+		case keyCtrlB, keyLeft:
+			s.pos = max(s.pos-1, 0)
+		case keyCtrlF, keyRight:
+			s.pos = min(s.pos+1, len(s.buf))
+		case keyCtrlK:
+			s.buf = s.buf[:s.pos]
+		case keyCtrlU:
+			s.buf = append([]rune{}, s.buf[s.pos:]...)
+			s.pos = 0
+		case keyCtrlW:
+			start := s.pos
+			for start > 0 && s.buf[start-1] == ' ' {
+				start--
+			}
+			for start > 0 && s.buf[start-1] != ' ' {
+				start--
+			}
+			s.buf = append(s.buf[:start], s.buf[s.pos:]...)
+			s.pos = start
+		case keyCtrlL:
+			fmt.Fprint(s.editor.out, "\x1b[H\x1b[2J")
+		case keyCtrlP, keyUp:
+			s.historyMove(-1)
+		case keyCtrlN, keyDown:
+			s.historyMove(1)
+		case keyCtrlR:
+			s.searching = true
+			s.query = nil
+			s.searchIndex = s.editor.History.Len()
+		default:
+			if key >= 0 && unicode.IsPrint(key) {
+				s.buf = append(s.buf[:s.pos], append([]rune{key}, s.buf[s.pos:]...)...)
+				s.pos++
+			}
+		}
+		s.lastWasTab = tab
+		s.refresh()
+	}
+}
+
+// handleSearchKey processes a key in reverse-search mode and reports
+// whether the line is complete.
+func (s *state) handleSearchKey(key rune) (bool, error) {
+	switch key {
+	case keyCtrlC:
+		return true, ErrInterrupted
+	case keyCtrlG, keyEscape:
+		s.searching = false
+	case keyEnter, '\n':
+		s.searching = false
+		return true, nil
+	case keyCtrlR:
+		s.search(s.searchIndex)
+	case keyBackspace, keyCtrlH:
+		if len(s.query) > 0 {
+			s.query = s.query[:len(s.query)-1]
+			s.search(s.editor.History.Len())
+		}
+	default:
+		if key >= 0 && unicode.IsPrint(key) {
+			s.query = append(s.query, key)
+			s.search(s.editor.History.Len())
+		} else {
+			// Any other key accepts the match for further editing.
+			s.searching = false
+		}
+	}
+	s.refresh()
+	return false, nil
+}
+
+func (s *state) search(from int) {
+	if i := s.editor.History.Search(string(s.query), from); i >= 0 {
+		s.searchIndex = i
+		s.buf = []rune(s.editor.History.At(i))
+		s.pos = len(s.buf)
+	}
+}
+
+func (s *state) historyMove(delta int) {
+	h := s.editor.History
+	next := s.histIndex + delta
+	if next < 0 || next > h.Len() {
+		return
+	}
+	if s.histIndex == h.Len() {
+		s.saved = s.buf
+	}
+	s.histIndex = next
+	if next == h.Len() {
+		s.buf = s.saved
+	} else {
+		s.buf = []rune(h.At(next))
+	}
+	s.pos = len(s.buf)
+}
+
+func (s *state) deleteAt(i int) {
+	if i < len(s.buf) {
+		s.buf = append(s.buf[:i], s.buf[i+1:]...)
+	}
+}
+
+func (s *state) complete() {
+	if s.editor.Complete == nil {
+		return
+	}
+	line := string(s.buf)
+	bytePos := len(string(s.buf[:s.pos]))
+	start, candidates := s.editor.Complete(line, bytePos)
+	if len(candidates) == 0 {
+		return
+	}
+
+	prefix := candidates[0]
+	for _, c := range candidates[1:] {
+		prefix = commonPrefix(prefix, c)
+	}
+	if len(candidates) == 1 {
+		prefix += " "
+	}
+
+	typed := line[start:bytePos]
+	if prefix != typed && strings.HasPrefix(prefix, typed) {
+		replaced := []rune(line[:start] + prefix)
+		s.buf = append(replaced, s.buf[s.pos:]...)
+		s.pos = len(replaced)
+		return
+	}
+
+	// Nothing more to insert: a second Tab lists the candidates.
+	if s.lastWasTab {
+		fmt.Fprint(s.editor.out, "\r\n"+strings.Join(candidates, "  ")+"\r\n")
+	}
+}
+
+// commonPrefix returns the longest common prefix of a and b that ends on
+// a rune boundary.
+func commonPrefix(a, b string) string {
+	i := 0
+	for _, r := range a {
+		if !strings.HasPrefix(b[i:], string(r)) {
+			break
+		}
+		i += utf8.RuneLen(r)
+	}
+	return a[:i]
+}
+
+// width is the number of terminal columns runes take: two for East Asian
+// wide and fullwidth characters, like kana and kanji, and one for the
+// others. Combining marks are counted as one column too.
+func width(runes []rune) int {
+	n := 0
+	for _, r := range runes {
+		n++
+		if isWide(r) {
+			n++
+		}
+	}
+	return n
+}
+
+func isWide(r rune) bool {
+	switch {
+	case r < 0x1100:
+		return false
+	case r <= 0x115f, // Hangul Jamo
+		r >= 0x2e80 && r <= 0xa4cf && r != 0x303f, // CJK, kana, Yi
+		r >= 0xac00 && r <= 0xd7a3,                // Hangul syllables
+		r >= 0xf900 && r <= 0xfaff,                // CJK compatibility ideographs
+		r >= 0xfe30 && r <= 0xfe4f,                // CJK compatibility forms
+		r >= 0xff00 && r <= 0xff60,                // fullwidth forms
+		r >= 0xffe0 && r <= 0xffe6,
+		r >= 0x1f300 && r <= 0x1f64f, // pictographs and emoticons
+		r >= 0x1f900 && r <= 0x1f9ff,
+		r >= 0x20000 && r <= 0x3fffd: // CJK extensions
+		return true
+	}
+	return false
+}
+
+func (s *state) refresh() {
+	prompt, text, cursor := s.prompt, string(s.buf), s.pos
+	if s.searching {
+		prompt = fmt.Sprintf("(reverse-i-search)`%s': ", string(s.query))
+	}
+	column := width([]rune(prompt)) + width(s.buf[:cursor])
+	fmt.Fprintf(s.editor.out, "\r%s%s\x1b[K\r", prompt, text)
+	if column > 0 {
+		fmt.Fprintf(s.editor.out, "\x1b[%dC", column)
+	}
+}
+
+// readKey reads one key press, decoding common escape sequences.
+func (s *state) readKey() (rune, error) {
+	r, _, err := s.editor.reader.ReadRune()
+	if err != nil || r != keyEscape {
+		return r, err
+	}
+	if s.editor.reader.Buffered() == 0 {
+		return keyEscape, nil
+	}
+	next, _, err := s.editor.reader.ReadRune()
+	if err != nil {
+		return 0, err
+	}
+	if next != '[' && next != 'O' {
+		return keyEscape, nil
+	}
+
+	var seq []rune
+	for {
+		c, _, err := s.editor.reader.ReadRune()
+		if err != nil {
+			return 0, err
+		}
+		seq = append(seq, c)
+		if c >= 0x40 && c <= 0x7e {
+			break
+		}
+	}
+	switch string(seq) {
+	case "A":
+		return keyUp, nil
+	case "B":
+		return keyDown, nil
+	case "C":
+		return keyRight, nil
+	case "D":
+		return keyLeft, nil
+	case "H", "1~", "7~":
+		return keyHome, nil
+	case "F", "4~", "8~":
+		return keyEnd, nil
+	case "3~":
+		return keyDelete, nil
+	}
+	return keyEscape, nil
+}
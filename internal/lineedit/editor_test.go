@@ -0,0 +1,165 @@
+package lineedit
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func run(t *testing.T, e *Editor, input string) (string, error) {
+	t.Helper()
+	e.reader = bufio.NewReader(strings.NewReader(input))
+	e.out = io.Discard
+	s := &state{editor: e, histIndex: e.History.Len()}
+	return s.run()
+}
+
+func newTestEditor(history ...string) *Editor {
+	e := &Editor{History: NewHistory(0)}
+	for _, line := range history {
+		e.History.Add(line)
+	}
+	return e
+}
+
+func TestEditing(t *testing.T) {
+	cases := map[string]string{
+		"map\r":                            "map",
+		"mpa\x7f\x7fap\r":                  "map",
+		"atch\x01c\r":                      "catch",
+		"catch pidgey\x17rattata\r":        "catch rattata",
+		"explore x\x1b[D\x1b[D\x0b\r":      "explore",
+		"help\x1b[D\x1b[D\x1b[3~\r":        "hep",
+		"inspect\x15pokedex\r":             "pokedex",
+		"ab\x1b[Hx\x1b[Fy\r":               "xaby",
+		"caught\x02\x02\x02\x02\x04\x06\r": "caght",
+	}
+	for input, want := range cases {
+		got, err := run(t, newTestEditor(), input)
+		if err != nil || got != want {
+			t.Errorf("%q: got %q, %v; want %q", input, got, err, want)
+		}
+	}
+}
+
+func TestControlKeys(t *testing.T) {
+	if _, err := run(t, newTestEditor(), "\x04"); !errors.Is(err, io.EOF) {
+		t.Errorf("Ctrl-D on an empty line: got %v, want io.EOF", err)
+	}
+	if _, err := run(t, newTestEditor(), "map\x03"); !errors.Is(err, ErrInterrupted) {
+		t.Errorf("Ctrl-C: got %v, want ErrInterrupted", err)
+	}
+}
+
+func TestHistoryNavigationAndSearch(t *testing.T) {
+	e := newTestEditor("map", "explore kanto-route-1-area", "catch pidgey")
+
+	if got, _ := run(t, e, "\x1b[A\x1b[A\r"); got != "explore kanto-route-1-area" {
+		t.Errorf("two ups: got %q", got)
+	}
+	if got, _ := run(t, e, "par\x1b[A\x1b[B\r"); got != "par" {
+		t.Errorf("up then down should restore the draft, got %q", got)
+	}
+	if got, _ := run(t, e, "\x12a\r"); got != "catch pidgey" {
+		t.Errorf("search 'a': got %q", got)
+	}
+	if got, _ := run(t, e, "\x12a\x12\x12\r"); got != "map" {
+		t.Errorf("search 'a' three times: got %q", got)
+	}
+	if got, _ := run(t, e, "x\x12zzz\x07\r"); got != "x" {
+		t.Errorf("cancelled search should keep the line, got %q", got)
+	}
+}
+
+func TestCompletion(t *testing.T) {
+	e := newTestEditor()
+	e.Complete = func(line string, pos int) (int, []string) {
+		start := strings.LastIndex(line[:pos], " ") + 1
+		var out []string
+		for _, c := range []string{"canalave-city-area", "cerulean-cave-1f", "catch"} {
+			if strings.HasPrefix(c, line[start:pos]) {
+				out = append(out, c)
+			}
+		}
+		return start, out
+	}
+
+	if got, _ := run(t, e, "cat\t\r"); got != "catch " {
+		t.Errorf("unique completion: got %q", got)
+	}
+	if got, _ := run(t, e, "explore can\t\r"); got != "explore canalave-city-area " {
+		t.Errorf("argument completion: got %q", got)
+	}
+	if got, _ := run(t, e, "explore c\t\t\r"); got != "explore c" {
+		t.Errorf("ambiguous completion: got %q", got)
+	}
+}
+
+func TestCompletionCandidates(t *testing.T) {
+	names := []string{"pidgey", "pidgeotto", "pidgeot", "ポッポ", "ポッチャマ"}
+	e := newTestEditor()
+	e.Complete = func(line string, pos int) (int, []string) {
+		var out []string
+		for _, name := range names {
+			if strings.HasPrefix(name, line[:pos]) {
+				out = append(out, name)
+			}
+		}
+		return 0, out
+	}
+
+	cases := map[string]string{
+		"pid\t\r":      "pidge",
+		"pidgeo\t\r":   "pidgeot",
+		"pidgeott\t\r": "pidgeotto ",
+		"ポ\t\r":        "ポッ",
+		"x\t\r":        "x",
+	}
+	for input, want := range cases {
+		if got, _ := run(t, e, input); got != want {
+			t.Errorf("%q: got %q, want %q", input, got, want)
+		}
+	}
+
+	var out strings.Builder
+	e.reader = bufio.NewReader(strings.NewReader("pidge\t\t\r"))
+	e.out = &out
+	s := &state{editor: e, histIndex: e.History.Len()}
+	if _, err := s.run(); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "\r\npidgey  pidgeotto  pidgeot\r\n") {
+		t.Errorf("second Tab did not list the candidates: %q", out.String())
+	}
+}
+
+func TestWidth(t *testing.T) {
+	cases := map[string]int{"": 0, "pikachu": 7, "ピカチュウ": 10, "Pokédex > ": 10, "図鑑 > ": 7}
+	for text, want := range cases {
+		if got := width([]rune(text)); got != want {
+			t.Errorf("width(%q) = %d, want %d", text, got, want)
+		}
+	}
+}
+
+func TestHistoryPersists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history")
+	h, err := LoadHistory(path, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{"map", "map", " ", "mapb", "pokedex"} {
+		h.Add(line)
+	}
+
+	h, err = LoadHistory(path, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h.Len() != 2 || h.At(0) != "mapb" || h.At(1) != "pokedex" {
+		t.Errorf("unexpected history: %v", h.lines)
+	}
+}
@@ -0,0 +1,118 @@
+package lineedit
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// History is a list of previously entered lines, optionally persisted to a
+// file that is appended to as lines are added.
+type History struct {
+	lines []string
+	max   int
+	path  string
+}
+
+// NewHistory returns an in-memory history keeping at most max lines.
+func NewHistory(max int) *History {
+	return &History{max: max}
+}
+
+// LoadHistory reads the history file at path, keeping the newest max
+// lines, and appends new lines to it. A missing file is not an error.
+func LoadHistory(path string, max int) (*History, error) {
+	h := &History{max: max, path: path}
+
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return h, nil
+	}
+	if err != nil {
+		return h, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if line := scanner.Text(); line != "" {
+			h.lines = append(h.lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return h, err
+	}
+
+	// Compact the file once it holds more lines than we keep.
+	if max > 0 && len(h.lines) > max {
+		h.trim()
+		if err := h.rewrite(); err != nil {
+			return h, err
+		}
+	}
+	return h, nil
+}
+
+// Len returns the number of lines in the history.
+func (h *History) Len() int {
+	return len(h.lines)
+}
+
+// At returns the i-th oldest line.
+func (h *History) At(i int) string {
+	return h.lines[i]
+}
+
+// Add appends line unless it is blank or repeats the previous line.
+func (h *History) Add(line string) error {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.Contains(line, "\n") {
+		return nil
+	}
+	if n := len(h.lines); n > 0 && h.lines[n-1] == line {
+		return nil
+	}
+	h.lines = append(h.lines, line)
+	h.trim()
+
+	if h.path == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(h.path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(h.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.WriteString(line + "\n")
+	return err
+}
+
+// Search returns the index of the newest line before index from that
+// contains query, or -1.
+func (h *History) Search(query string, from int) int {
+	for i := min(from, len(h.lines)) - 1; i >= 0; i-- {
+		if strings.Contains(h.lines[i], query) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (h *History) trim() {
+	if h.max > 0 && len(h.lines) > h.max {
+		h.lines = h.lines[len(h.lines)-h.max:]
+	}
+}
+
+func (h *History) rewrite() error {
+	tmp := h.path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(strings.Join(h.lines, "\n")+"\n"), 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, h.path)
+}
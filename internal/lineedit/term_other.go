@@ -0,0 +1,13 @@
+//go:build !linux && !darwin
+
+package lineedit
+
+import "errors"
+
+func isTerminal(fd uintptr) bool {
+	return false
+}
+
+func makeRaw(fd uintptr) (func(), error) {
+	return nil, errors.New("raw mode is not supported on this platform")
+}
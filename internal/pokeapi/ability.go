@@ -0,0 +1,10 @@
+package pokeapi
+
+import "context"
+
+// GetAbility fetches a single ability by name or id.
+func (c *Client) GetAbility(ctx context.Context, name string) (Ability, error) {
+	var ability Ability
+	err := c.get(ctx, c.resourceURL("ability", name), &ability)
+	return ability, err
+}
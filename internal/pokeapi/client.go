@@ -0,0 +1,278 @@
+package pokeapi
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/anegri01f01/pokegocli/internal/diskcache"
+)
+
+// DefaultBaseURL is the public PokeAPI v2 endpoint.
+const DefaultBaseURL = "https://pokeapi.co/api/v2"
+
+// Responses are kept on disk for these durations. Paginated listings change
+// when PokeAPI adds resources; individual resources almost never do.
+const (
+	listTTL     = 24 * time.Hour
+	resourceTTL = 7 * 24 * time.Hour
+)
+
+// Client is a typed PokeAPI client. Responses are cached by URL in memory
+// and, when configured, on disk.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	cache      *memoryCache
+	disk       *diskcache.Cache
+	offline    bool
+	timeout    time.Duration
+
+	// mu guards stats.
+	mu    sync.Mutex
+	stats CacheStats
+}
+
+// CacheStats counts where responses were served from.
+type CacheStats struct {
+	MemoryEntries int
+	MemoryHits    int
+	DiskHits      int
+	Fetches       int
+	Disk          *diskcache.Stats
+}
+
+// Option configures a Client.
+type Option func(*clientOptions)
+
+type clientOptions struct {
+	baseURL       string
+	httpClient    *http.Client
+	cacheInterval time.Duration
+	disk          *diskcache.Cache
+	offline       bool
+	timeout       time.Duration
+	retries       int
+	limiter       *RateLimiter
+}
+
+// WithBaseURL points the client at a PokeAPI-compatible server.
+func WithBaseURL(baseURL string) Option {
+	return func(o *clientOptions) {
+		o.baseURL = baseURL
+	}
+}
+
+// WithHTTPClient replaces the http.Client used for requests.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(o *clientOptions) {
+		o.httpClient = httpClient
+	}
+}
+
+// WithCacheInterval sets how long responses stay in the in-memory cache.
+func WithCacheInterval(interval time.Duration) Option {
+	return func(o *clientOptions) {
+		o.cacheInterval = interval
+	}
+}
+
+// WithDiskCache adds a persistent cache tier behind the in-memory cache.
+func WithDiskCache(disk *diskcache.Cache) Option {
+	return func(o *clientOptions) {
+		o.disk = disk
+	}
+}
+
+// WithOffline stops the client from making network requests. Only cached
+// responses, including expired ones, are served.
+func WithOffline(offline bool) Option {
+	return func(o *clientOptions) {
+		o.offline = offline
+	}
+}
+
+// WithTimeout bounds each client call, retries included. Zero means no
+// timeout.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *clientOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WithRetries retries 429 and 5xx responses up to retries times.
+func WithRetries(retries int) Option {
+	return func(o *clientOptions) {
+		o.retries = retries
+	}
+}
+
+// WithRateLimit throttles requests to perSecond with bursts of burst.
+func WithRateLimit(perSecond float64, burst int) Option {
+	return func(o *clientOptions) {
+		o.limiter = NewRateLimiter(perSecond, burst)
+	}
+}
+
+// NewClient returns a Client talking to DefaultBaseURL unless overridden.
+func NewClient(opts ...Option) *Client {
+	o := clientOptions{
+		baseURL:       DefaultBaseURL,
+		httpClient:    http.DefaultClient,
+		cacheInterval: time.Minute * 5,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	httpClient := o.httpClient
+	if o.retries > 0 || o.limiter != nil {
+		wrapped := *o.httpClient
+		wrapped.Transport = &Transport{
+			Base:      o.httpClient.Transport,
+			Limiter:   o.limiter,
+			Retries:   o.retries,
+			BaseDelay: 250 * time.Millisecond,
+			MaxDelay:  30 * time.Second,
+		}
+		httpClient = &wrapped
+	}
+
+	return &Client{
+		baseURL:    strings.TrimRight(o.baseURL, "/"),
+		httpClient: httpClient,
+		cache:      newMemoryCache(o.cacheInterval),
+		disk:       o.disk,
+		offline:    o.offline,
+		timeout:    o.timeout,
+	}
+}
+
+// Offline reports whether the client is restricted to cached responses.
+func (c *Client) Offline() bool {
+	return c.offline
+}
+
+// CacheStats reports cache hits and network fetches since the client was
+// created or the cache was last cleared.
+func (c *Client) CacheStats() CacheStats {
+	c.mu.Lock()
+	stats := c.stats
+	c.mu.Unlock()
+	stats.MemoryEntries = c.cache.Len()
+	if c.disk != nil {
+		disk := c.disk.Stats()
+		stats.Disk = &disk
+	}
+	return stats
+}
+
+// ClearCache empties both the in-memory and the on-disk cache.
+func (c *Client) ClearCache() error {
+	c.cache.Clear()
+	c.mu.Lock()
+	c.stats = CacheStats{}
+	c.mu.Unlock()
+	if c.disk != nil {
+		return c.disk.Clear()
+	}
+	return nil
+}
+
+// BaseURL returns the API root the client sends requests to.
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+func (c *Client) resourceURL(resource, name string) string {
+	if name == "" {
+		return c.baseURL + "/" + resource
+	}
+	return c.baseURL + "/" + resource + "/" + name
+}
+
+// get fetches url, caching the raw body, and decodes it into v. Failures
+// are reported as *Error.
+func (c *Client) get(ctx context.Context, url string, v any) error {
+	if val, ok := c.cache.Get(url); ok {
+		c.count(&c.stats.MemoryHits)
+		return c.decode(url, val, v)
+	}
+	if c.disk != nil {
+		get := c.disk.Get
+		if c.offline {
+			get = c.disk.GetStale
+		}
+		if val, ok := get(url); ok {
+			c.count(&c.stats.DiskHits)
+			c.cache.Add(url, val)
+			return c.decode(url, val, v)
+		}
+	}
+	if c.offline {
+		return c.newError(KindNotCached, url, nil)
+	}
+
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return c.newError(KindNetwork, url, err)
+	}
+	c.count(&c.stats.Fetches)
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return c.newError(KindNetwork, url, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode > 299 {
+		apiErr := c.newError(statusKind(res.StatusCode), url, nil)
+		apiErr.StatusCode = res.StatusCode
+		return apiErr
+	}
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return c.newError(KindNetwork, url, err)
+	}
+	if err := c.decode(url, body, v); err != nil {
+		return err
+	}
+
+	c.cache.Add(url, body)
+	if c.disk != nil {
+		// A response that cannot be written to disk is still a good response.
+		c.disk.Add(url, body, c.ttl(url))
+	}
+	return nil
+}
+
+// count increments one of the stats.
+func (c *Client) count(stat *int) {
+	c.mu.Lock()
+	*stat++
+	c.mu.Unlock()
+}
+
+func (c *Client) decode(url string, body []byte, v any) error {
+	if err := json.Unmarshal(body, v); err != nil {
+		return c.newError(KindDecode, url, err)
+	}
+	return nil
+}
+
+// ttl picks how long a response stays in the disk cache.
+func (c *Client) ttl(url string) time.Duration {
+	rest := strings.TrimPrefix(url, c.baseURL+"/")
+	if strings.Contains(rest, "?") || !strings.Contains(strings.Trim(rest, "/"), "/") {
+		return listTTL
+	}
+	return resourceTTL
+}
@@ -0,0 +1,223 @@
+package pokeapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/anegri01f01/pokegocli/internal/diskcache"
+)
+
+func TestGetPokemonUsesCache(t *testing.T) {
+	hits := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		if r.URL.Path != "/pokemon/pikachu" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"id":25,"name":"pikachu","base_experience":112}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient(WithBaseURL(srv.URL), WithHTTPClient(srv.Client()))
+	for i := 0; i < 2; i++ {
+		pokemon, err := client.GetPokemon(context.Background(), "pikachu")
+		if err != nil {
+			t.Fatalf("GetPokemon: %v", err)
+		}
+		if pokemon.ID != 25 || pokemon.BaseExperience != 112 {
+			t.Errorf("unexpected pokemon: %+v", pokemon)
+		}
+	}
+	if hits != 1 {
+		t.Errorf("expected 1 request, got %d", hits)
+	}
+}
+
+func TestClearCacheWhileFetching(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Write([]byte(`{"id":25,"name":"pikachu"}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient(WithBaseURL(srv.URL), WithHTTPClient(srv.Client()))
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				if _, err := client.GetPokemon(context.Background(), "pikachu"); err != nil {
+					t.Error(err)
+				}
+				client.CacheStats()
+			}
+		}()
+	}
+	for i := 0; i < 10; i++ {
+		client.ClearCache()
+	}
+	wg.Wait()
+
+	goroutines := runtime.NumGoroutine()
+	for i := 0; i < 10; i++ {
+		client.ClearCache()
+	}
+	if n := runtime.NumGoroutine(); n > goroutines {
+		t.Errorf("clearing the cache started goroutines: %d, was %d", n, goroutines)
+	}
+	before := hits.Load()
+	client.GetPokemon(context.Background(), "pikachu")
+	if stats := client.CacheStats(); hits.Load() != before+1 || stats.Fetches != 1 || stats.MemoryHits != 0 || stats.MemoryEntries != 1 {
+		t.Errorf("expected a fetch after clearing, got %+v", stats)
+	}
+}
+
+func TestMemoryCacheExpires(t *testing.T) {
+	m := newMemoryCache(10 * time.Millisecond)
+	m.Add("a", []byte("1"))
+	if val, ok := m.Get("a"); !ok || string(val) != "1" {
+		t.Fatalf("Get = %q, %v", val, ok)
+	}
+	time.Sleep(20 * time.Millisecond)
+	m.Add("b", []byte("2"))
+	if m.Len() != 1 {
+		t.Errorf("expired entries were not swept, %d left", m.Len())
+	}
+	if _, ok := m.Get("a"); ok {
+		t.Error("expected an expired entry to miss")
+	}
+	m.Clear()
+	if _, ok := m.Get("b"); ok || m.Len() != 0 {
+		t.Error("expected Clear to drop every entry")
+	}
+}
+
+func TestListLocationAreasFirstPage(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/location-area" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"count":2,"next":"next-page","previous":null,"results":[{"name":"a","url":"u"},{"name":"b","url":"u"}]}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient(WithBaseURL(srv.URL + "/"))
+	list, err := client.ListLocationAreas(context.Background(), "")
+	if err != nil {
+		t.Fatalf("ListLocationAreas: %v", err)
+	}
+	if len(list.Results) != 2 || list.Next != "next-page" || list.Previous != "" {
+		t.Errorf("unexpected list: %+v", list)
+	}
+}
+
+func TestGetClassifiesErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/pokemon/limited":
+			w.WriteHeader(http.StatusTooManyRequests)
+		case "/pokemon/broken":
+			w.WriteHeader(http.StatusBadGateway)
+		case "/pokemon/garbled":
+			w.Write([]byte("<html>"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	client := NewClient(WithBaseURL(srv.URL))
+	cases := map[string]ErrorKind{
+		"pikachuu": KindNotFound,
+		"limited":  KindRateLimited,
+		"broken":   KindServer,
+		"garbled":  KindDecode,
+	}
+	for name, kind := range cases {
+		_, err := client.GetPokemon(context.Background(), name)
+		var apiErr *Error
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("%s: expected *Error, got %v", name, err)
+		}
+		if apiErr.Kind != kind || apiErr.Resource != "pokemon" || apiErr.Name != name {
+			t.Errorf("%s: got kind %v for %s/%s", name, apiErr.Kind, apiErr.Resource, apiErr.Name)
+		}
+	}
+
+	srv.Close()
+	_, err := client.GetPokemon(context.Background(), "pidgey")
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.Kind != KindNetwork {
+		t.Errorf("expected a network error, got %v", err)
+	}
+}
+
+func TestDiskCacheServesNewClient(t *testing.T) {
+	hits := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.Write([]byte(`{"id":16,"name":"pidgey"}`))
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	for i := 0; i < 2; i++ {
+		disk, err := diskcache.Open(dir, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		client := NewClient(WithBaseURL(srv.URL), WithDiskCache(disk))
+		if _, err := client.GetPokemon(context.Background(), "pidgey"); err != nil {
+			t.Fatalf("GetPokemon: %v", err)
+		}
+		stats := client.CacheStats()
+		if i == 1 && (stats.DiskHits != 1 || stats.Fetches != 0) {
+			t.Errorf("expected a disk hit on the second run, got %+v", stats)
+		}
+	}
+	if hits != 1 {
+		t.Errorf("expected 1 request, got %d", hits)
+	}
+}
+
+func TestOfflineServesStaleDiskEntriesOnly(t *testing.T) {
+	disk, err := diskcache.Open(t.TempDir(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	disk.Add("http://pokeapi.invalid/pokemon/pidgey", []byte(`{"id":16,"name":"pidgey"}`), -time.Hour)
+
+	client := NewClient(
+		WithBaseURL("http://pokeapi.invalid"),
+		WithHTTPClient(&http.Client{Transport: failingTransport{t}}),
+		WithDiskCache(disk),
+		WithOffline(true),
+	)
+	if _, err := client.GetPokemon(context.Background(), "pidgey"); err != nil {
+		t.Fatalf("expected the stale entry to be served, got %v", err)
+	}
+
+	_, err = client.GetPokemon(context.Background(), "mewtwo")
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.Kind != KindNotCached || apiErr.Name != "mewtwo" {
+		t.Fatalf("expected a not cached error for pokemon/mewtwo, got %v", err)
+	}
+}
+
+type failingTransport struct{ t *testing.T }
+
+func (f failingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	f.t.Errorf("unexpected request to %s", r.URL)
+	return nil, errors.New("network disabled")
+}
@@ -0,0 +1,96 @@
+package pokeapi
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ErrorKind classifies why a request failed.
+type ErrorKind int
+
+const (
+	// KindNetwork means PokeAPI could not be reached.
+	KindNetwork ErrorKind = iota + 1
+	// KindNotFound means the requested resource does not exist.
+	KindNotFound
+	// KindRateLimited means PokeAPI refused the request with 429.
+	KindRateLimited
+	// KindServer means PokeAPI answered with a 5xx status.
+	KindServer
+	// KindStatus means PokeAPI answered with any other unexpected status.
+	KindStatus
+	// KindDecode means the response body was not the expected JSON.
+	KindDecode
+	// KindNotCached means the client is offline and nothing was cached.
+	KindNotCached
+)
+
+func (k ErrorKind) String() string {
+	switch k {
+	case KindNetwork:
+		return "network error"
+	case KindNotFound:
+		return "not found"
+	case KindRateLimited:
+		return "rate limited"
+	case KindServer:
+		return "server error"
+	case KindStatus:
+		return "unexpected status"
+	case KindDecode:
+		return "decode error"
+	case KindNotCached:
+		return "not cached"
+	}
+	return "unknown error"
+}
+
+// Error is returned by every Client method that fails.
+type Error struct {
+	Kind ErrorKind
+	// Resource and Name identify what was requested, e.g. "pokemon" and
+	// "pikachu". Name is empty for listings.
+	Resource   string
+	Name       string
+	URL        string
+	StatusCode int
+	Err        error
+}
+
+func (e *Error) Error() string {
+	target := e.Resource
+	if e.Name != "" {
+		target += "/" + e.Name
+	}
+	switch e.Kind {
+	case KindNotFound, KindRateLimited, KindServer, KindStatus:
+		return fmt.Sprintf("%s %q: %d %s", e.Kind, target, e.StatusCode, http.StatusText(e.StatusCode))
+	case KindNotCached:
+		return fmt.Sprintf("offline mode: %q is not cached (run prefetch while online)", target)
+	}
+	return fmt.Sprintf("%s %q: %v", e.Kind, target, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// newError builds an Error for url, splitting it into resource and name.
+func (c *Client) newError(kind ErrorKind, url string, err error) *Error {
+	path, _, _ := strings.Cut(strings.TrimPrefix(url, c.baseURL+"/"), "?")
+	resource, name, _ := strings.Cut(strings.Trim(path, "/"), "/")
+	return &Error{Kind: kind, Resource: resource, Name: name, URL: url, Err: err}
+}
+
+func statusKind(status int) ErrorKind {
+	switch {
+	case status == http.StatusNotFound:
+		return KindNotFound
+	case status == http.StatusTooManyRequests:
+		return KindRateLimited
+	case status >= 500:
+		return KindServer
+	}
+	return KindStatus
+}
@@ -0,0 +1,39 @@
+package pokeapi
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// GetEvolutionChain fetches an evolution chain by id.
+func (c *Client) GetEvolutionChain(ctx context.Context, id int) (EvolutionChain, error) {
+	var chain EvolutionChain
+	err := c.get(ctx, c.resourceURL("evolution-chain", strconv.Itoa(id)), &chain)
+	return chain, err
+}
+
+// GetSpeciesEvolutionChain follows a species to the evolution chain it
+// belongs to.
+func (c *Client) GetSpeciesEvolutionChain(ctx context.Context, species string) (EvolutionChain, error) {
+	s, err := c.GetPokemonSpecies(ctx, species)
+	if err != nil {
+		return EvolutionChain{}, err
+	}
+	id, err := s.EvolutionChain.ID()
+	if err != nil {
+		return EvolutionChain{}, err
+	}
+	return c.GetEvolutionChain(ctx, id)
+}
+
+// ID returns the id at the end of the resource's URL.
+func (r APIResource) ID() (int, error) {
+	id, err := strconv.Atoi(path.Base(strings.TrimSuffix(r.URL, "/")))
+	if err != nil {
+		return 0, fmt.Errorf("no id in resource URL %q", r.URL)
+	}
+	return id, nil
+}
@@ -0,0 +1,54 @@
+package pokeapi
+
+import (
+	"context"
+	"net/url"
+)
+
+// allResults is larger than any PokeAPI collection, so a single page holds
+// every entry.
+const allResults = "100000"
+
+// ListAllLocationAreas returns every location area.
+func (c *Client) ListAllLocationAreas(ctx context.Context) ([]NamedResource, error) {
+	return c.listAll(ctx, "location-area")
+}
+
+// ListAllPokemon returns every Pokemon.
+func (c *Client) ListAllPokemon(ctx context.Context) ([]NamedResource, error) {
+	return c.listAll(ctx, "pokemon")
+}
+
+// ListAllTypes returns every type.
+func (c *Client) ListAllTypes(ctx context.Context) ([]NamedResource, error) {
+	return c.listAll(ctx, "type")
+}
+
+// ListAllSpecies returns every Pokemon species.
+func (c *Client) ListAllSpecies(ctx context.Context) ([]NamedResource, error) {
+	return c.listAll(ctx, "pokemon-species")
+}
+
+// ListAllMoves returns every move.
+func (c *Client) ListAllMoves(ctx context.Context) ([]NamedResource, error) {
+	return c.listAll(ctx, "move")
+}
+
+// ListAllAbilities returns every ability.
+func (c *Client) ListAllAbilities(ctx context.Context) ([]NamedResource, error) {
+	return c.listAll(ctx, "ability")
+}
+
+// ListAllStats returns every stat.
+func (c *Client) ListAllStats(ctx context.Context) ([]NamedResource, error) {
+	return c.listAll(ctx, "stat")
+}
+
+func (c *Client) listAll(ctx context.Context, resource string) ([]NamedResource, error) {
+	query := url.Values{"offset": {"0"}, "limit": {allResults}}
+	var list ResourceList
+	if err := c.get(ctx, c.resourceURL(resource, "")+"?"+query.Encode(), &list); err != nil {
+		return nil, err
+	}
+	return list.Results, nil
+}
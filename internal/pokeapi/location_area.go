@@ -0,0 +1,21 @@
+package pokeapi
+
+import "context"
+
+// ListLocationAreas returns the page of location areas at pageURL, or the
+// first page when pageURL is empty.
+func (c *Client) ListLocationAreas(ctx context.Context, pageURL string) (ResourceList, error) {
+	if pageURL == "" {
+		pageURL = c.resourceURL("location-area", "")
+	}
+	var list ResourceList
+	err := c.get(ctx, pageURL, &list)
+	return list, err
+}
+
+// GetLocationArea fetches a single location area by name or id.
+func (c *Client) GetLocationArea(ctx context.Context, name string) (LocationArea, error) {
+	var area LocationArea
+	err := c.get(ctx, c.resourceURL("location-area", name), &area)
+	return area, err
+}
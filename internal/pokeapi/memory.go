@@ -0,0 +1,69 @@
+package pokeapi
+
+import (
+	"sync"
+	"time"
+)
+
+// memoryCache keeps response bodies for an interval. Expired entries are
+// dropped as they are looked up and swept when new ones are added, so no
+// goroutine outlives the client.
+type memoryCache struct {
+	interval time.Duration
+
+	mu        sync.Mutex
+	entries   map[string]memoryEntry
+	lastSweep time.Time
+}
+
+type memoryEntry struct {
+	added time.Time
+	val   []byte
+}
+
+func newMemoryCache(interval time.Duration) *memoryCache {
+	return &memoryCache{interval: interval, entries: make(map[string]memoryEntry), lastSweep: time.Now()}
+}
+
+func (m *memoryCache) Get(key string) ([]byte, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if time.Since(e.added) > m.interval {
+		delete(m.entries, key)
+		return nil, false
+	}
+	return e.val, true
+}
+
+func (m *memoryCache) Add(key string, val []byte) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := time.Now()
+	if now.Sub(m.lastSweep) > m.interval {
+		for k, e := range m.entries {
+			if now.Sub(e.added) > m.interval {
+				delete(m.entries, k)
+			}
+		}
+		m.lastSweep = now
+	}
+	m.entries[key] = memoryEntry{added: now, val: val}
+}
+
+// Clear drops every entry.
+func (m *memoryCache) Clear() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries = make(map[string]memoryEntry)
+}
+
+// Len counts the entries, expired ones not yet dropped included.
+func (m *memoryCache) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.entries)
+}
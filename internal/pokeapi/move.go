@@ -0,0 +1,10 @@
+package pokeapi
+
+import "context"
+
+// GetMove fetches a single move by name or id.
+func (c *Client) GetMove(ctx context.Context, name string) (Move, error) {
+	var move Move
+	err := c.get(ctx, c.resourceURL("move", name), &move)
+	return move, err
+}
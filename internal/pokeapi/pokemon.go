@@ -0,0 +1,10 @@
+package pokeapi
+
+import "context"
+
+// GetPokemon fetches a single Pokemon by name or id.
+func (c *Client) GetPokemon(ctx context.Context, name string) (Pokemon, error) {
+	var pokemon Pokemon
+	err := c.get(ctx, c.resourceURL("pokemon", name), &pokemon)
+	return pokemon, err
+}
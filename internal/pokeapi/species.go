@@ -0,0 +1,10 @@
+package pokeapi
+
+import "context"
+
+// GetPokemonSpecies fetches a single Pokemon species by name or id.
+func (c *Client) GetPokemonSpecies(ctx context.Context, name string) (PokemonSpecies, error) {
+	var species PokemonSpecies
+	err := c.get(ctx, c.resourceURL("pokemon-species", name), &species)
+	return species, err
+}
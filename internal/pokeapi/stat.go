@@ -0,0 +1,10 @@
+package pokeapi
+
+import "context"
+
+// GetStat fetches a single stat by name or id.
+func (c *Client) GetStat(ctx context.Context, name string) (Stat, error) {
+	var stat Stat
+	err := c.get(ctx, c.resourceURL("stat", name), &stat)
+	return stat, err
+}
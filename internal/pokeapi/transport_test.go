@@ -0,0 +1,92 @@
+package pokeapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestTransportRetriesUntilSuccess(t *testing.T) {
+	attempts := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		switch attempts {
+		case 1:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.Write([]byte(`{"id":19,"name":"rattata"}`))
+		}
+	}))
+	defer srv.Close()
+
+	client := NewClient(WithBaseURL(srv.URL), WithRetries(3))
+	client.httpClient.Transport.(*Transport).BaseDelay = time.Millisecond
+
+	pokemon, err := client.GetPokemon(context.Background(), "rattata")
+	if err != nil {
+		t.Fatalf("GetPokemon: %v", err)
+	}
+	if pokemon.ID != 19 || attempts != 3 {
+		t.Errorf("got id %d after %d attempts", pokemon.ID, attempts)
+	}
+}
+
+func TestTransportGivesUpAfterRetries(t *testing.T) {
+	attempts := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	client := NewClient(WithBaseURL(srv.URL), WithRetries(2))
+	client.httpClient.Transport.(*Transport).BaseDelay = time.Millisecond
+
+	_, err := client.GetPokemon(context.Background(), "rattata")
+	if apiErr, ok := err.(*Error); !ok || apiErr.Kind != KindServer {
+		t.Fatalf("expected a server error, got %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestClientTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	client := NewClient(WithBaseURL(srv.URL), WithTimeout(20*time.Millisecond))
+	_, err := client.GetPokemon(context.Background(), "slowpoke")
+	if apiErr, ok := err.(*Error); !ok || apiErr.Kind != KindNetwork {
+		t.Fatalf("expected a network error, got %v", err)
+	}
+}
+
+func TestRateLimiterThrottlesAfterBurst(t *testing.T) {
+	limiter := NewRateLimiter(50, 2)
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		if err := limiter.Wait(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Two requests come from the burst, the other two wait ~20ms each.
+	if elapsed := time.Since(start); elapsed < 30*time.Millisecond {
+		t.Errorf("expected throttling, took %v", elapsed)
+	}
+
+	slow := NewRateLimiter(0.001, 1)
+	slow.Wait(context.Background())
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := slow.Wait(ctx); err == nil {
+		t.Error("expected Wait to give up on a cancelled context")
+	}
+}
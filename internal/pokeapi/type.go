@@ -0,0 +1,10 @@
+package pokeapi
+
+import "context"
+
+// GetType fetches a single type by name or id.
+func (c *Client) GetType(ctx context.Context, name string) (Type, error) {
+	var t Type
+	err := c.get(ctx, c.resourceURL("type", name), &t)
+	return t, err
+}
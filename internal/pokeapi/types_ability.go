@@ -0,0 +1,27 @@
+package pokeapi
+
+// Ability is the /ability/{name} payload.
+type Ability struct {
+	EffectEntries []struct {
+		Effect      string        `json:"effect"`
+		Language    NamedResource `json:"language"`
+		ShortEffect string        `json:"short_effect"`
+	} `json:"effect_entries"`
+	FlavorTextEntries []struct {
+		FlavorText   string        `json:"flavor_text"`
+		Language     NamedResource `json:"language"`
+		VersionGroup NamedResource `json:"version_group"`
+	} `json:"flavor_text_entries"`
+	Generation   NamedResource `json:"generation"`
+	ID           int           `json:"id"`
+	IsMainSeries bool          `json:"is_main_series"`
+	Name         string        `json:"name"`
+	Names        []Name        `json:"names"`
+	// Pokemon lists every Pokemon that can have the ability, and whether
+	// it is their hidden ability.
+	Pokemon []struct {
+		IsHidden bool          `json:"is_hidden"`
+		Pokemon  NamedResource `json:"pokemon"`
+		Slot     int           `json:"slot"`
+	} `json:"pokemon"`
+}
@@ -0,0 +1,42 @@
+package pokeapi
+
+// EvolutionChain is the /evolution-chain/{id} payload.
+type EvolutionChain struct {
+	BabyTriggerItem *NamedResource `json:"baby_trigger_item"`
+	Chain           ChainLink      `json:"chain"`
+	ID              int            `json:"id"`
+}
+
+// ChainLink is one species of an evolution chain and what it evolves into.
+type ChainLink struct {
+	// EvolutionDetails are the ways to evolve into this species; any one
+	// of them is enough.
+	EvolutionDetails []EvolutionDetail `json:"evolution_details"`
+	EvolvesTo        []ChainLink       `json:"evolves_to"`
+	IsBaby           bool              `json:"is_baby"`
+	Species          NamedResource     `json:"species"`
+}
+
+// EvolutionDetail is one set of conditions for an evolution. Unset
+// conditions are nil, false or empty.
+type EvolutionDetail struct {
+	// Gender is 1 for female and 2 for male.
+	Gender                *int           `json:"gender"`
+	HeldItem              *NamedResource `json:"held_item"`
+	Item                  *NamedResource `json:"item"`
+	KnownMove             *NamedResource `json:"known_move"`
+	KnownMoveType         *NamedResource `json:"known_move_type"`
+	Location              *NamedResource `json:"location"`
+	MinAffection          *int           `json:"min_affection"`
+	MinBeauty             *int           `json:"min_beauty"`
+	MinHappiness          *int           `json:"min_happiness"`
+	MinLevel              *int           `json:"min_level"`
+	NeedsOverworldRain    bool           `json:"needs_overworld_rain"`
+	PartySpecies          *NamedResource `json:"party_species"`
+	PartyType             *NamedResource `json:"party_type"`
+	RelativePhysicalStats *int           `json:"relative_physical_stats"`
+	TimeOfDay             string         `json:"time_of_day"`
+	TradeSpecies          *NamedResource `json:"trade_species"`
+	Trigger               NamedResource  `json:"trigger"`
+	TurnUpsideDown        bool           `json:"turn_upside_down"`
+}
@@ -0,0 +1,41 @@
+package pokeapi
+
+// Move is the /move/{name} payload.
+type Move struct {
+	// Accuracy is a percentage, or nil for moves that never miss.
+	Accuracy      *int          `json:"accuracy"`
+	DamageClass   NamedResource `json:"damage_class"`
+	EffectChance  *int          `json:"effect_chance"`
+	EffectEntries []struct {
+		Effect      string        `json:"effect"`
+		Language    NamedResource `json:"language"`
+		ShortEffect string        `json:"short_effect"`
+	} `json:"effect_entries"`
+	FlavorTextEntries []struct {
+		FlavorText   string        `json:"flavor_text"`
+		Language     NamedResource `json:"language"`
+		VersionGroup NamedResource `json:"version_group"`
+	} `json:"flavor_text_entries"`
+	ID               int             `json:"id"`
+	LearnedByPokemon []NamedResource `json:"learned_by_pokemon"`
+	Meta             *struct {
+		CritRate     int `json:"crit_rate"`
+		Drain        int `json:"drain"`
+		FlinchChance int `json:"flinch_chance"`
+		Healing      int `json:"healing"`
+		StatChance   int `json:"stat_chance"`
+	} `json:"meta"`
+	Name  string `json:"name"`
+	Names []Name `json:"names"`
+	// Power is nil for status moves and moves whose damage is worked out
+	// some other way.
+	Power       *int `json:"power"`
+	PP          int  `json:"pp"`
+	Priority    int  `json:"priority"`
+	StatChanges []struct {
+		Change int           `json:"change"`
+		Stat   NamedResource `json:"stat"`
+	} `json:"stat_changes"`
+	Target NamedResource `json:"target"`
+	Type   NamedResource `json:"type"`
+}
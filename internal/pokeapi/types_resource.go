@@ -0,0 +1,39 @@
+package pokeapi
+
+// NamedResource is a reference to another PokeAPI resource.
+type NamedResource struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// ResourceList is one page of a paginated PokeAPI listing.
+type ResourceList struct {
+	Count    int             `json:"count"`
+	Next     string          `json:"next"`
+	Previous string          `json:"previous"`
+	Results  []NamedResource `json:"results"`
+}
+
+// Name is a resource's name in one language.
+type Name struct {
+	Name     string        `json:"name"`
+	Language NamedResource `json:"language"`
+}
+
+// APIResource is a reference to an unnamed PokeAPI resource.
+type APIResource struct {
+	URL string `json:"url"`
+}
+
+// Localized returns the name in a language, or the English one when there
+// is none. It returns "" when neither is listed.
+func Localized(names []Name, lang string) string {
+	for _, l := range []string{lang, "en"} {
+		for _, n := range names {
+			if n.Language.Name == l {
+				return n.Name
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,39 @@
+package pokeapi
+
+// PokemonSpecies is the /pokemon-species/{name} payload.
+type PokemonSpecies struct {
+	BaseHappiness      int             `json:"base_happiness"`
+	CaptureRate        int             `json:"capture_rate"`
+	Color              NamedResource   `json:"color"`
+	EggGroups          []NamedResource `json:"egg_groups"`
+	EvolutionChain     APIResource     `json:"evolution_chain"`
+	EvolvesFromSpecies *NamedResource  `json:"evolves_from_species"`
+	FlavorTextEntries  []struct {
+		FlavorText string        `json:"flavor_text"`
+		Language   NamedResource `json:"language"`
+		Version    NamedResource `json:"version"`
+	} `json:"flavor_text_entries"`
+	// GenderRate is the chance of being female in eighths, or -1 for
+	// genderless species.
+	GenderRate int `json:"gender_rate"`
+	Genera     []struct {
+		Genus    string        `json:"genus"`
+		Language NamedResource `json:"language"`
+	} `json:"genera"`
+	Generation           NamedResource  `json:"generation"`
+	GrowthRate           NamedResource  `json:"growth_rate"`
+	Habitat              *NamedResource `json:"habitat"`
+	HasGenderDifferences bool           `json:"has_gender_differences"`
+	HatchCounter         int            `json:"hatch_counter"`
+	ID                   int            `json:"id"`
+	IsBaby               bool           `json:"is_baby"`
+	IsLegendary          bool           `json:"is_legendary"`
+	IsMythical           bool           `json:"is_mythical"`
+	Name                 string         `json:"name"`
+	Names                []Name         `json:"names"`
+	Order                int            `json:"order"`
+	Varieties            []struct {
+		IsDefault bool          `json:"is_default"`
+		Pokemon   NamedResource `json:"pokemon"`
+	} `json:"varieties"`
+}
@@ -0,0 +1,11 @@
+package pokeapi
+
+// Stat is the /stat/{name} payload.
+type Stat struct {
+	GameIndex       int            `json:"game_index"`
+	ID              int            `json:"id"`
+	IsBattleOnly    bool           `json:"is_battle_only"`
+	MoveDamageClass *NamedResource `json:"move_damage_class"`
+	Name            string         `json:"name"`
+	Names           []Name         `json:"names"`
+}
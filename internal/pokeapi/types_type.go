@@ -0,0 +1,17 @@
+package pokeapi
+
+// Type is the /type/{name} payload.
+type Type struct {
+	DamageRelations struct {
+		DoubleDamageFrom []NamedResource `json:"double_damage_from"`
+		DoubleDamageTo   []NamedResource `json:"double_damage_to"`
+		HalfDamageFrom   []NamedResource `json:"half_damage_from"`
+		HalfDamageTo     []NamedResource `json:"half_damage_to"`
+		NoDamageFrom     []NamedResource `json:"no_damage_from"`
+		NoDamageTo       []NamedResource `json:"no_damage_to"`
+	} `json:"damage_relations"`
+	ID              int            `json:"id"`
+	MoveDamageClass *NamedResource `json:"move_damage_class"`
+	Name            string         `json:"name"`
+	Names           []Name         `json:"names"`
+}
@@ -0,0 +1,359 @@
+// Package pokedex persists the caught Pokemon collection and the item
+// inventory between sessions.
+package pokedex
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/anegri01f01/pokegocli/internal/battle"
+	"github.com/anegri01f01/pokegocli/internal/pokeapi"
+	"github.com/anegri01f01/pokegocli/internal/stats"
+	"github.com/anegri01f01/pokegocli/internal/xdg"
+)
+
+// SchemaVersion is the version written by Save. Version 2 added the
+// inventory and the explored areas, version 3 the session, version 4 the
+// individual caught Pokemon, version 5 their friendship and version 6
+// their ability.
+const SchemaVersion = 6
+
+// DefaultFriendship is the friendship of most species when caught, given
+// to Pokemon caught before friendship was kept.
+const DefaultFriendship = 70
+
+// MaxFriendship is the highest friendship a Pokemon can reach.
+const MaxFriendship = 255
+
+// StarterBalls is the inventory of a new trainer, keyed by item name.
+var StarterBalls = map[string]int{"poke-ball": 10}
+
+// FileName is the name of the Pokedex file in the data directory.
+const FileName = "pokedex.json"
+
+// ErrCorrupt is wrapped by Load when a file could not be decoded and was
+// moved aside.
+var ErrCorrupt = errors.New("pokedex file is corrupt")
+
+// ErrNewerVersion is returned by Load for files written by a newer release.
+var ErrNewerVersion = errors.New("pokedex file was written by a newer version")
+
+// Pokedex is the caught collection.
+type Pokedex struct {
+	Version int `json:"version"`
+	// Pokemon holds the PokeAPI data of every Pokemon ever caught, keyed by
+	// name. Entries stay after the caught Pokemon are released.
+	Pokemon map[string]pokeapi.Pokemon `json:"pokemon"`
+	// Caught lists the Pokemon the trainer owns, in the order caught.
+	Caught []CaughtPokemon `json:"caught"`
+	// NextID is the ID the next caught Pokemon gets.
+	NextID int `json:"next_id"`
+	// Inventory counts the items carried, keyed by PokeAPI item name.
+	Inventory map[string]int `json:"inventory"`
+	// Explored records the location areas explored at least once.
+	Explored map[string]bool `json:"explored"`
+	Session  Session         `json:"session"`
+}
+
+// Session is where the trainer is and what they are facing, kept so that
+// one-shot commands can pick up where the last one left off.
+type Session struct {
+	Area    string `json:"area,omitempty"`
+	Version string `json:"version,omitempty"`
+	Method  string `json:"method,omitempty"`
+	// Encounter is the wild Pokemon currently faced, if any.
+	Encounter *Encounter `json:"encounter,omitempty"`
+	// Battle is the battle being fought, if any.
+	Battle *battle.Battle `json:"battle,omitempty"`
+}
+
+// CaughtPokemon is one Pokemon the trainer owns.
+type CaughtPokemon struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Nickname string `json:"nickname,omitempty"`
+	Level    int    `json:"level"`
+	// IVs are the individual values, from 0 to 31.
+	IVs    stats.Stats `json:"ivs"`
+	Nature string      `json:"nature"`
+	// Gender is empty for Pokemon migrated from before version 4 until the
+	// species' gender rate is known.
+	Gender string `json:"gender"`
+	Shiny  bool   `json:"shiny"`
+	// Ability is the name of the ability it has, one of its species'.
+	Ability string `json:"ability,omitempty"`
+	// Friendship grows with battles won, from 0 to MaxFriendship.
+	Friendship int       `json:"friendship"`
+	Ball       string    `json:"ball,omitempty"`
+	CaughtAt   time.Time `json:"caught_at"`
+	Location   string    `json:"location,omitempty"`
+}
+
+// DisplayName is the nickname, or the Pokemon's name without one.
+func (c CaughtPokemon) DisplayName() string {
+	if c.Nickname != "" {
+		return c.Nickname
+	}
+	return c.Name
+}
+
+// Encounter is a wild Pokemon the trainer is facing.
+type Encounter struct {
+	Pokemon string `json:"pokemon"`
+	Level   int    `json:"level"`
+	Method  string `json:"method"`
+	// Throws counts the balls thrown at it so far.
+	Throws int `json:"throws"`
+}
+
+// New returns an empty Pokedex with the starter inventory.
+func New() *Pokedex {
+	return &Pokedex{
+		Version:   SchemaVersion,
+		Pokemon:   make(map[string]pokeapi.Pokemon),
+		NextID:    1,
+		Inventory: starterInventory(),
+		Explored:  make(map[string]bool),
+	}
+}
+
+func starterInventory() map[string]int {
+	inv := make(map[string]int, len(StarterBalls))
+	for item, n := range StarterBalls {
+		inv[item] = n
+	}
+	return inv
+}
+
+// DefaultPath is the Pokedex file under the user's XDG data directory.
+func DefaultPath() (string, error) {
+	dir, err := xdg.DataHome()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, FileName), nil
+}
+
+// Names returns the names of the Pokemon ever caught in alphabetical order.
+func (p *Pokedex) Names() []string {
+	names := make([]string, 0, len(p.Pokemon))
+	for name := range p.Pokemon {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Reset removes every caught Pokemon and ends a battle they were fighting.
+// The inventory and the rest of the session are kept.
+func (p *Pokedex) Reset() {
+	p.Pokemon = make(map[string]pokeapi.Pokemon)
+	p.Caught = nil
+	p.Session.Battle = nil
+}
+
+// Add stores a newly caught Pokemon, giving it the next ID, and returns it.
+func (p *Pokedex) Add(c CaughtPokemon, data pokeapi.Pokemon) CaughtPokemon {
+	c.ID = p.NextID
+	p.NextID++
+	p.Caught = append(p.Caught, c)
+	p.Pokemon[c.Name] = data
+	return c
+}
+
+// Get returns the caught Pokemon with an ID.
+func (p *Pokedex) Get(id int) (*CaughtPokemon, bool) {
+	for i := range p.Caught {
+		if p.Caught[i].ID == id {
+			return &p.Caught[i], true
+		}
+	}
+	return nil, false
+}
+
+// Release removes the caught Pokemon with an ID and reports whether there
+// was one.
+func (p *Pokedex) Release(id int) bool {
+	for i := range p.Caught {
+		if p.Caught[i].ID == id {
+			p.Caught = append(p.Caught[:i], p.Caught[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Items returns the names of the items carried, in alphabetical order.
+func (p *Pokedex) Items() []string {
+	items := make([]string, 0, len(p.Inventory))
+	for item, n := range p.Inventory {
+		if n > 0 {
+			items = append(items, item)
+		}
+	}
+	sort.Strings(items)
+	return items
+}
+
+// AddItem adds n of an item to the inventory.
+func (p *Pokedex) AddItem(item string, n int) {
+	p.Inventory[item] += n
+}
+
+// UseItem takes one of an item from the inventory and reports whether
+// there was one.
+func (p *Pokedex) UseItem(item string) bool {
+	if p.Inventory[item] <= 0 {
+		return false
+	}
+	p.Inventory[item]--
+	if p.Inventory[item] == 0 {
+		delete(p.Inventory, item)
+	}
+	return true
+}
+
+// Load reads the Pokedex at path. A missing file yields an empty Pokedex.
+// A file that cannot be decoded is renamed to path.corrupt-<unix time> and
+// an empty Pokedex is returned together with an error wrapping ErrCorrupt.
+func Load(path string) (*Pokedex, error) {
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return New(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := decode(raw)
+	if errors.Is(err, ErrNewerVersion) {
+		return nil, err
+	}
+	if err != nil {
+		backup := fmt.Sprintf("%s.corrupt-%d", path, time.Now().Unix())
+		if renameErr := os.Rename(path, backup); renameErr != nil {
+			return nil, fmt.Errorf("%w: %v (could not move it aside: %v)", ErrCorrupt, err, renameErr)
+		}
+		return New(), fmt.Errorf("%w: %v (moved to %s)", ErrCorrupt, err, backup)
+	}
+	return p, nil
+}
+
+// Open reads the Pokedex file at path, failing when it is missing or cannot
+// be decoded. Unlike Load it never moves the file aside.
+func Open(path string) (*Pokedex, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	p, err := decode(raw)
+	if err != nil && !errors.Is(err, ErrNewerVersion) {
+		return nil, fmt.Errorf("%s is not a pokedex file: %w", path, err)
+	}
+	return p, err
+}
+
+func decode(raw []byte) (*Pokedex, error) {
+	var header struct {
+		Version int `json:"version"`
+	}
+	if err := json.Unmarshal(raw, &header); err != nil {
+		return nil, err
+	}
+	switch {
+	case header.Version < 1:
+		return nil, fmt.Errorf("missing schema version")
+	case header.Version > SchemaVersion:
+		return nil, fmt.Errorf("%w (version %d)", ErrNewerVersion, header.Version)
+	}
+
+	p := &Pokedex{}
+	if err := json.Unmarshal(raw, p); err != nil {
+		return nil, err
+	}
+	if p.Pokemon == nil {
+		p.Pokemon = make(map[string]pokeapi.Pokemon)
+	}
+	if p.Inventory == nil {
+		p.Inventory = make(map[string]int)
+	}
+	if p.Explored == nil {
+		p.Explored = make(map[string]bool)
+	}
+	p.migrate(header.Version)
+	p.Version = SchemaVersion
+	return p, nil
+}
+
+// migrate upgrades a Pokedex decoded from an older schema version.
+func (p *Pokedex) migrate(from int) {
+	if from < 2 {
+		p.Inventory = starterInventory()
+	}
+	if from < 4 {
+		// Older files kept one Pokemon per species with no details; give each
+		// a plain level 5 instance. The species' gender rate was never kept,
+		// so the gender is rolled when the Pokemon is first inspected.
+		p.NextID = 1
+		for _, name := range p.Names() {
+			p.Add(CaughtPokemon{
+				Name:   name,
+				Level:  5,
+				IVs:    stats.Stats{HP: 15, Attack: 15, Defense: 15, SpecialAttack: 15, SpecialDefense: 15, Speed: 15},
+				Nature: "hardy",
+			}, p.Pokemon[name])
+		}
+	}
+	if from < 5 {
+		for i := range p.Caught {
+			p.Caught[i].Friendship = DefaultFriendship
+		}
+	}
+	if from < 6 {
+		// Pokemon caught before abilities were kept get the species' first.
+		for i := range p.Caught {
+			for _, a := range p.Pokemon[p.Caught[i].Name].Abilities {
+				if a.Slot == 1 {
+					p.Caught[i].Ability = a.Ability.Name
+				}
+			}
+		}
+	}
+}
+
+// Save atomically writes the Pokedex to path, creating parent directories.
+func (p *Pokedex) Save(path string) error {
+	p.Version = SchemaVersion
+	raw, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(raw); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
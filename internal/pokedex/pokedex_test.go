@@ -0,0 +1,179 @@
+package pokedex
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/anegri01f01/pokegocli/internal/pokeapi"
+)
+
+func TestSaveAndLoadRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", FileName)
+
+	dex := New()
+	dex.Pokemon["pikachu"] = pokeapi.Pokemon{ID: 25, Name: "pikachu"}
+	if err := dex.Save(path); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if loaded.Version != SchemaVersion || loaded.Pokemon["pikachu"].ID != 25 {
+		t.Errorf("unexpected pokedex: %+v", loaded)
+	}
+
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 {
+		t.Errorf("expected only the pokedex file, found %d entries", len(entries))
+	}
+}
+
+func TestLoadMissingFileIsEmpty(t *testing.T) {
+	dex, err := Load(filepath.Join(t.TempDir(), FileName))
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(dex.Pokemon) != 0 {
+		t.Errorf("expected an empty pokedex, got %v", dex.Names())
+	}
+}
+
+func TestLoadMovesCorruptFileAside(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, FileName)
+	os.WriteFile(path, []byte("{not json"), 0o644)
+
+	dex, err := Load(path)
+	if !errors.Is(err, ErrCorrupt) {
+		t.Fatalf("expected ErrCorrupt, got %v", err)
+	}
+	if dex == nil || len(dex.Pokemon) != 0 {
+		t.Fatalf("expected a fresh pokedex, got %+v", dex)
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("corrupt file was not moved aside")
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 || !strings.HasPrefix(entries[0].Name(), FileName+".corrupt-") {
+		t.Errorf("unexpected directory contents: %v", entries)
+	}
+}
+
+func TestOpenLeavesBadFilesAlone(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := Open(filepath.Join(dir, "missing.json")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected a missing file to fail, got %v", err)
+	}
+	path := filepath.Join(dir, "notes.txt")
+	os.WriteFile(path, []byte("not a pokedex"), 0o644)
+	if _, err := Open(path); err == nil {
+		t.Error("expected a file that is not a pokedex to fail")
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 || entries[0].Name() != "notes.txt" {
+		t.Errorf("unexpected directory contents: %v", entries)
+	}
+}
+
+func TestLoadRejectsNewerVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	os.WriteFile(path, []byte(`{"version": 99, "pokemon": {}}`), 0o644)
+
+	if _, err := Load(path); !errors.Is(err, ErrNewerVersion) {
+		t.Fatalf("expected ErrNewerVersion, got %v", err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("newer file should be left in place: %v", err)
+	}
+}
+
+func TestLoadMigratesVersion1(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	os.WriteFile(path, []byte(`{"version": 1, "pokemon": {"pidgey": {"id": 16, "name": "pidgey"}}}`), 0o644)
+
+	dex, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if dex.Version != SchemaVersion || dex.Pokemon["pidgey"].ID != 16 {
+		t.Errorf("unexpected pokedex: %+v", dex)
+	}
+	if dex.Inventory["poke-ball"] != StarterBalls["poke-ball"] {
+		t.Errorf("expected the starter inventory, got %v", dex.Inventory)
+	}
+}
+
+func TestInventory(t *testing.T) {
+	dex := New()
+	dex.AddItem("ultra-ball", 1)
+	if !dex.UseItem("ultra-ball") {
+		t.Fatal("expected to use the ultra ball")
+	}
+	if dex.UseItem("ultra-ball") {
+		t.Error("used an ultra ball that was not there")
+	}
+	if got := dex.Items(); len(got) != 1 || got[0] != "poke-ball" {
+		t.Errorf("Items() = %v", got)
+	}
+}
+
+func TestLoadMigratesSpeciesToInstances(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	os.WriteFile(path, []byte(`{"version": 3, "pokemon": {"rattata": {"id": 19, "name": "rattata"}, "pidgey": {"id": 16, "name": "pidgey"}}}`), 0o644)
+
+	dex, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(dex.Caught) != 2 || dex.Caught[0].Name != "pidgey" || dex.Caught[0].ID != 1 || dex.Caught[1].ID != 2 {
+		t.Fatalf("unexpected instances %+v", dex.Caught)
+	}
+	if dex.NextID != 3 || dex.Caught[1].Level != 5 || dex.Caught[1].Friendship != DefaultFriendship || dex.Caught[1].Gender != "" {
+		t.Errorf("unexpected pokedex %+v", dex)
+	}
+}
+
+func TestLoadMigratesAbility(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	os.WriteFile(path, []byte(`{"version": 5, "pokemon": {"pikachu": {"id": 25, "name": "pikachu", "abilities": [
+		{"ability": {"name": "lightning-rod"}, "is_hidden": true, "slot": 3},
+		{"ability": {"name": "static"}, "slot": 1}]}},
+		"caught": [{"id": 1, "name": "pikachu", "level": 5}], "next_id": 2}`), 0o644)
+
+	dex, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if dex.Caught[0].Ability != "static" {
+		t.Errorf("expected the first ability, got %q", dex.Caught[0].Ability)
+	}
+}
+
+func TestAddGetRelease(t *testing.T) {
+	dex := New()
+	first := dex.Add(CaughtPokemon{Name: "pidgey", Level: 3}, pokeapi.Pokemon{ID: 16, Name: "pidgey"})
+	second := dex.Add(CaughtPokemon{Name: "pidgey", Level: 4}, pokeapi.Pokemon{ID: 16, Name: "pidgey"})
+	if first.ID == second.ID {
+		t.Fatalf("both pidgey got ID %d", first.ID)
+	}
+
+	c, ok := dex.Get(second.ID)
+	if !ok || c.Level != 4 {
+		t.Fatalf("Get(%d) = %+v, %v", second.ID, c, ok)
+	}
+	c.Nickname = "Sir Pidge"
+	if got, _ := dex.Get(second.ID); got.DisplayName() != "Sir Pidge" {
+		t.Errorf("rename was not kept: %+v", got)
+	}
+
+	if !dex.Release(first.ID) || dex.Release(first.ID) {
+		t.Error("Release should succeed exactly once")
+	}
+	if len(dex.Caught) != 1 || dex.Pokemon["pidgey"].ID != 16 {
+		t.Errorf("unexpected pokedex after release: %+v", dex)
+	}
+}
@@ -0,0 +1,85 @@
+// Package render formats command results as text, tables, JSON or YAML.
+package render
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// Format selects how results are written.
+type Format string
+
+const (
+	Text  Format = "text"
+	Table Format = "table"
+	JSON  Format = "json"
+	YAML  Format = "yaml"
+)
+
+// Formats lists every supported format.
+var Formats = []Format{Text, Table, JSON, YAML}
+
+// ParseFormat validates a format name.
+func ParseFormat(name string) (Format, error) {
+	for _, f := range Formats {
+		if string(f) == strings.ToLower(name) {
+			return f, nil
+		}
+	}
+	return "", fmt.Errorf("unknown output format %q (want text, table, json or yaml)", name)
+}
+
+// Texter is implemented by results with a human-readable rendering.
+type Texter interface {
+	Text(w io.Writer) error
+}
+
+// Tabler is implemented by results that can be shown as a table.
+type Tabler interface {
+	Table() (header []string, rows [][]string)
+}
+
+// Write renders v in format. Text falls back to a table and then to YAML;
+// a table falls back to text.
+func Write(w io.Writer, format Format, v any) error {
+	switch format {
+	case JSON:
+		raw, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "%s\n", raw)
+		return err
+	case YAML:
+		return writeYAML(w, v)
+	case Table:
+		if t, ok := v.(Tabler); ok {
+			return writeTable(w, t)
+		}
+		if t, ok := v.(Texter); ok {
+			return t.Text(w)
+		}
+		return writeYAML(w, v)
+	}
+
+	if t, ok := v.(Texter); ok {
+		return t.Text(w)
+	}
+	if t, ok := v.(Tabler); ok {
+		return writeTable(w, t)
+	}
+	return writeYAML(w, v)
+}
+
+func writeTable(w io.Writer, t Tabler) error {
+	header, rows := t.Table()
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.ToUpper(strings.Join(header, "\t")))
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,102 @@
+package render
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+type sample struct {
+	Name    string            `json:"name"`
+	Level   int               `json:"level"`
+	Shiny   bool              `json:"shiny"`
+	Types   []string          `json:"types"`
+	Stats   []stat            `json:"stats"`
+	Extra   map[string]string `json:"extra,omitempty"`
+	Note    string            `json:"note"`
+	Nothing []string          `json:"nothing"`
+}
+
+type stat struct {
+	Name  string `json:"name"`
+	Value int    `json:"value"`
+}
+
+func (s sample) Table() ([]string, [][]string) {
+	return []string{"name", "level"}, [][]string{{s.Name, "5"}}
+}
+
+func TestYAML(t *testing.T) {
+	v := sample{
+		Name:  "pidgey",
+		Level: 5,
+		Types: []string{"normal", "flying"},
+		Stats: []stat{{"hp", 40}, {"attack", 45}},
+		Note:  "yes",
+	}
+	var buf bytes.Buffer
+	if err := Write(&buf, YAML, v); err != nil {
+		t.Fatal(err)
+	}
+	want := `name: pidgey
+level: 5
+shiny: false
+types:
+  - normal
+  - flying
+stats:
+  - name: hp
+    value: 40
+  - name: attack
+    value: 45
+note: "yes"
+nothing: null
+`
+	if buf.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestQuote(t *testing.T) {
+	cases := map[string]string{
+		"pikachu":       "pikachu",
+		"Mr. Mime":      "Mr. Mime",
+		"":              `""`,
+		"12":            `"12"`,
+		"true":          `"true"`,
+		"- dash":        `"- dash"`,
+		"key: value":    `"key: value"`,
+		"line\nbreak":   `"line\nbreak"`,
+		" padded":       `" padded"`,
+		"farfetch'd":    "farfetch'd",
+		"type: null #1": `"type: null #1"`,
+	}
+	for in, want := range cases {
+		if got := quote(in); got != want {
+			t.Errorf("quote(%q) = %s, want %s", in, got, want)
+		}
+	}
+}
+
+func TestWriteFormats(t *testing.T) {
+	v := sample{Name: "pidgey"}
+
+	var buf bytes.Buffer
+	Write(&buf, Table, v)
+	if want := "NAME    LEVEL\npidgey  5\n"; buf.String() != want {
+		t.Errorf("table: got %q, want %q", buf.String(), want)
+	}
+
+	buf.Reset()
+	Write(&buf, JSON, stat{"hp", 40})
+	if want := "{\n  \"name\": \"hp\",\n  \"value\": 40\n}\n"; buf.String() != want {
+		t.Errorf("json: got %q, want %q", buf.String(), want)
+	}
+
+	if err := Write(io.Discard, Text, []int{}); err != nil {
+		t.Errorf("text fallback: %v", err)
+	}
+	if _, err := ParseFormat("xml"); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
@@ -0,0 +1,152 @@
+package render
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// writeYAML encodes v as YAML. Values go through encoding/json first so
+// field names, omitempty and custom marshalers match the JSON output, and
+// object keys keep their JSON order.
+func writeYAML(w io.Writer, v any) error {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	root, err := decodeNode(dec)
+	if err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	if root.isScalar() || root.isEmpty() {
+		b.WriteString(root.scalar() + "\n")
+	} else {
+		root.write(&b, 0)
+	}
+	_, err = io.WriteString(w, b.String())
+	return err
+}
+
+type node struct {
+	kind   byte // 'o'bject, 'a'rray or 's'calar
+	keys   []string
+	values []*node
+	value  any
+}
+
+func decodeNode(dec *json.Decoder) (*node, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch tok {
+	case json.Delim('{'):
+		n := &node{kind: 'o'}
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			child, err := decodeNode(dec)
+			if err != nil {
+				return nil, err
+			}
+			n.keys = append(n.keys, key.(string))
+			n.values = append(n.values, child)
+		}
+		_, err := dec.Token()
+		return n, err
+	case json.Delim('['):
+		n := &node{kind: 'a'}
+		for dec.More() {
+			child, err := decodeNode(dec)
+			if err != nil {
+				return nil, err
+			}
+			n.values = append(n.values, child)
+		}
+		_, err := dec.Token()
+		return n, err
+	}
+	return &node{kind: 's', value: tok}, nil
+}
+
+func (n *node) isScalar() bool {
+	return n.kind == 's'
+}
+
+func (n *node) isEmpty() bool {
+	return n.kind != 's' && len(n.values) == 0
+}
+
+func (n *node) scalar() string {
+	switch n.kind {
+	case 'o':
+		return "{}"
+	case 'a':
+		return "[]"
+	}
+	switch v := n.value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return strconv.FormatBool(v)
+	case json.Number:
+		return v.String()
+	case string:
+		return quote(v)
+	}
+	return fmt.Sprint(n.value)
+}
+
+// write emits a non-empty object or array at the given indent.
+func (n *node) write(b *strings.Builder, indent int) {
+	pad := strings.Repeat("  ", indent)
+	for i, child := range n.values {
+		if n.kind == 'o' {
+			b.WriteString(pad + quote(n.keys[i]) + ":")
+		} else {
+			b.WriteString(pad + "-")
+		}
+
+		switch {
+		case child.isScalar() || child.isEmpty():
+			b.WriteString(" " + child.scalar() + "\n")
+		case n.kind == 'a' && child.kind == 'o':
+			// Start the object on the dash line: "- key: value".
+			var nested strings.Builder
+			child.write(&nested, indent+1)
+			b.WriteString(" " + strings.TrimPrefix(nested.String(), pad+"  "))
+		default:
+			b.WriteString("\n")
+			child.write(b, indent+1)
+		}
+	}
+}
+
+// quote returns s as a plain YAML scalar when that is unambiguous and as a
+// double-quoted string otherwise.
+func quote(s string) string {
+	if s == "" || strings.TrimSpace(s) != s || strings.ContainsAny(s, "\n\t\"\\") {
+		return strconv.Quote(s)
+	}
+	switch strings.ToLower(s) {
+	case "null", "~", "true", "false", "yes", "no", "on", "off", "y", "n":
+		return strconv.Quote(s)
+	}
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		return strconv.Quote(s)
+	}
+	if strings.ContainsAny(s[:1], "-?:,[]{}#&*!|>'%@`") ||
+		strings.Contains(s, ": ") || strings.Contains(s, " #") || strings.HasSuffix(s, ":") {
+		return strconv.Quote(s)
+	}
+	return s
+}
@@ -0,0 +1,191 @@
+// Package stats computes a Pokemon's actual stats from its species' base
+// stats, level, individual values and nature, as the games do, and rolls
+// the random traits of wild Pokemon.
+package stats
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"github.com/anegri01f01/pokegocli/internal/pokeapi"
+)
+
+// MaxIV is the highest individual value.
+const MaxIV = 31
+
+// ShinyOdds is the one-in-n chance of a shiny Pokemon.
+const ShinyOdds = 4096
+
+// HiddenAbilityOdds is the one-in-n chance of a Pokemon having its
+// species' hidden ability.
+const HiddenAbilityOdds = 20
+
+// Names lists the stats in the order the games show them, by PokeAPI name.
+var Names = []string{"hp", "attack", "defense", "special-attack", "special-defense", "speed"}
+
+// Stats is a value for each of the six stats.
+type Stats struct {
+	HP             int `json:"hp"`
+	Attack         int `json:"attack"`
+	Defense        int `json:"defense"`
+	SpecialAttack  int `json:"special-attack"`
+	SpecialDefense int `json:"special-defense"`
+	Speed          int `json:"speed"`
+}
+
+// Get returns a stat by PokeAPI name.
+func (s Stats) Get(name string) int {
+	if p := s.field(name); p != nil {
+		return *p
+	}
+	return 0
+}
+
+func (s *Stats) field(name string) *int {
+	switch name {
+	case "hp":
+		return &s.HP
+	case "attack":
+		return &s.Attack
+	case "defense":
+		return &s.Defense
+	case "special-attack":
+		return &s.SpecialAttack
+	case "special-defense":
+		return &s.SpecialDefense
+	case "speed":
+		return &s.Speed
+	}
+	return nil
+}
+
+// Base returns a Pokemon's base stats.
+func Base(p pokeapi.Pokemon) Stats {
+	var s Stats
+	for _, stat := range p.Stats {
+		if f := s.field(stat.Stat.Name); f != nil {
+			*f = stat.BaseStat
+		}
+	}
+	return s
+}
+
+// Nature raises one stat by a tenth and lowers another. Neutral natures
+// raise and lower the same stat.
+type Nature struct {
+	Name     string
+	Increase string
+	Decrease string
+}
+
+// Natures lists the 25 natures in their index order.
+var Natures = []Nature{
+	{"hardy", "attack", "attack"}, {"lonely", "attack", "defense"}, {"brave", "attack", "speed"},
+	{"adamant", "attack", "special-attack"}, {"naughty", "attack", "special-defense"},
+	{"bold", "defense", "attack"}, {"docile", "defense", "defense"}, {"relaxed", "defense", "speed"},
+	{"impish", "defense", "special-attack"}, {"lax", "defense", "special-defense"},
+	{"timid", "speed", "attack"}, {"hasty", "speed", "defense"}, {"serious", "speed", "speed"},
+	{"jolly", "speed", "special-attack"}, {"naive", "speed", "special-defense"},
+	{"modest", "special-attack", "attack"}, {"mild", "special-attack", "defense"},
+	{"quiet", "special-attack", "speed"}, {"bashful", "special-attack", "special-attack"},
+	{"rash", "special-attack", "special-defense"},
+	{"calm", "special-defense", "attack"}, {"gentle", "special-defense", "defense"},
+	{"sassy", "special-defense", "speed"}, {"careful", "special-defense", "special-attack"},
+	{"quirky", "special-defense", "special-defense"},
+}
+
+// LookupNature finds a nature by name.
+func LookupNature(name string) (Nature, error) {
+	for _, n := range Natures {
+		if n.Name == strings.ToLower(name) {
+			return n, nil
+		}
+	}
+	return Nature{}, fmt.Errorf("unknown nature %q", name)
+}
+
+// modifier returns the nature's multiplier for a stat, in tenths.
+func (n Nature) modifier(stat string) int {
+	switch {
+	case n.Increase == n.Decrease:
+		return 10
+	case stat == n.Increase:
+		return 11
+	case stat == n.Decrease:
+		return 9
+	}
+	return 10
+}
+
+// Compute returns the actual stats of a Pokemon with no effort values.
+func Compute(base, ivs Stats, level int, nature Nature) Stats {
+	var s Stats
+	for _, name := range Names {
+		b, iv := base.Get(name), ivs.Get(name)
+		if name == "hp" {
+			s.HP = (2*b+iv)*level/100 + level + 10
+			continue
+		}
+		*s.field(name) = ((2*b+iv)*level/100 + 5) * nature.modifier(name) / 10
+	}
+	return s
+}
+
+// RollIVs picks individual values from 0 to MaxIV.
+func RollIVs(rng *rand.Rand) Stats {
+	var s Stats
+	for _, name := range Names {
+		*s.field(name) = rng.Intn(MaxIV + 1)
+	}
+	return s
+}
+
+// RollNature picks one of the natures.
+func RollNature(rng *rand.Rand) Nature {
+	return Natures[rng.Intn(len(Natures))]
+}
+
+// Genders a Pokemon can have.
+const (
+	Male       = "male"
+	Female     = "female"
+	Genderless = "genderless"
+)
+
+// RollGender picks Male, Female or Genderless from a species' gender_rate,
+// the chance of being female in eighths or -1.
+func RollGender(rng *rand.Rand, genderRate int) string {
+	switch {
+	case genderRate < 0:
+		return Genderless
+	case rng.Intn(8) < genderRate:
+		return Female
+	}
+	return Male
+}
+
+// RollShiny reports whether a Pokemon is shiny.
+func RollShiny(rng *rand.Rand) bool {
+	return rng.Intn(ShinyOdds) == 0
+}
+
+// RollAbility picks one of a Pokemon's regular abilities, or now and then
+// its hidden ability. It returns "" for a Pokemon without abilities.
+func RollAbility(rng *rand.Rand, data pokeapi.Pokemon) string {
+	var regular, hidden []string
+	for _, a := range data.Abilities {
+		if a.IsHidden {
+			hidden = append(hidden, a.Ability.Name)
+		} else {
+			regular = append(regular, a.Ability.Name)
+		}
+	}
+	if len(hidden) > 0 && (len(regular) == 0 || rng.Intn(HiddenAbilityOdds) == 0) {
+		return hidden[rng.Intn(len(hidden))]
+	}
+	if len(regular) == 0 {
+		return ""
+	}
+	return regular[rng.Intn(len(regular))]
+}
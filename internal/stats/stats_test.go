@@ -0,0 +1,63 @@
+package stats
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+
+	"github.com/anegri01f01/pokegocli/internal/pokeapi"
+)
+
+func TestCompute(t *testing.T) {
+	// Bulbapedia's level 78 adamant Garchomp, without its effort values.
+	base := Stats{HP: 108, Attack: 130, Defense: 95, SpecialAttack: 80, SpecialDefense: 85, Speed: 102}
+	ivs := Stats{HP: 24, Attack: 12, Defense: 30, SpecialAttack: 16, SpecialDefense: 23, Speed: 5}
+	adamant, err := LookupNature("adamant")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := Compute(base, ivs, 78, adamant)
+	want := Stats{HP: 275, Attack: 238, Defense: 176, SpecialAttack: 127, SpecialDefense: 155, Speed: 168}
+	if got != want {
+		t.Errorf("Compute() = %+v, want %+v", got, want)
+	}
+}
+
+func TestRolls(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		ivs := RollIVs(rng)
+		for _, name := range Names {
+			if iv := ivs.Get(name); iv < 0 || iv > MaxIV {
+				t.Fatalf("IV %s = %d", name, iv)
+			}
+		}
+		if g := RollGender(rng, -1); g != Genderless {
+			t.Fatalf("RollGender(-1) = %q", g)
+		}
+		if g := RollGender(rng, 8); g != Female {
+			t.Fatalf("RollGender(8) = %q", g)
+		}
+		if g := RollGender(rng, 0); g != Male {
+			t.Fatalf("RollGender(0) = %q", g)
+		}
+	}
+	var data pokeapi.Pokemon
+	json.Unmarshal([]byte(`{"abilities": [
+		{"ability": {"name": "static"}, "slot": 1},
+		{"ability": {"name": "lightning-rod"}, "is_hidden": true, "slot": 3}]}`), &data)
+	counts := map[string]int{}
+	for i := 0; i < 1000; i++ {
+		counts[RollAbility(rng, data)]++
+	}
+	if len(counts) != 2 || counts["lightning-rod"] == 0 || counts["lightning-rod"] > counts["static"]/5 {
+		t.Errorf("RollAbility counts %v", counts)
+	}
+	if a := RollAbility(rng, pokeapi.Pokemon{}); a != "" {
+		t.Errorf("RollAbility without abilities = %q", a)
+	}
+	if len(Natures) != 25 {
+		t.Errorf("%d natures", len(Natures))
+	}
+}
@@ -0,0 +1,119 @@
+// Package typechart works out type effectiveness from the damage relations
+// PokeAPI lists for each type.
+package typechart
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/anegri01f01/pokegocli/internal/pokeapi"
+)
+
+// Chart holds the damage multiplier of every attacking type against every
+// defending type.
+type Chart struct {
+	types []string
+	// multipliers[attack][defend] holds the pairs that are not 1.
+	multipliers map[string]map[string]float64
+}
+
+// New builds a chart from PokeAPI types, in the order given.
+func New(types []pokeapi.Type) *Chart {
+	c := &Chart{multipliers: make(map[string]map[string]float64)}
+	set := func(attack, defend string, m float64) {
+		if c.multipliers[attack] == nil {
+			c.multipliers[attack] = make(map[string]float64)
+		}
+		c.multipliers[attack][defend] = m
+	}
+	for _, t := range types {
+		c.types = append(c.types, t.Name)
+		rel := t.DamageRelations
+		for _, r := range rel.DoubleDamageTo {
+			set(t.Name, r.Name, 2)
+		}
+		for _, r := range rel.HalfDamageTo {
+			set(t.Name, r.Name, 0.5)
+		}
+		for _, r := range rel.NoDamageTo {
+			set(t.Name, r.Name, 0)
+		}
+	}
+	return c
+}
+
+// Types returns the names of the types in the chart.
+func (c *Chart) Types() []string {
+	return slices.Clone(c.types)
+}
+
+// Has reports whether the chart knows a type.
+func (c *Chart) Has(name string) bool {
+	return slices.Contains(c.types, name)
+}
+
+// Parse reads one or two types written like fire or fire/flying.
+func (c *Chart) Parse(s string) ([]string, error) {
+	types := strings.Split(strings.ToLower(s), "/")
+	if len(types) > 2 {
+		return nil, fmt.Errorf("a pokemon has at most two types, got %q", s)
+	}
+	for _, t := range types {
+		if !c.Has(t) {
+			return nil, fmt.Errorf("unknown type %q", t)
+		}
+	}
+	if len(types) == 2 && types[0] == types[1] {
+		types = types[:1]
+	}
+	return types, nil
+}
+
+// Effectiveness returns the multiplier of an attack of one type against a
+// Pokemon with the given types: 0, 0.25, 0.5, 1, 2 or 4.
+func (c *Chart) Effectiveness(attack string, defend []string) float64 {
+	eff := 1.0
+	for _, d := range defend {
+		if m, ok := c.multipliers[attack][d]; ok {
+			eff *= m
+		}
+	}
+	return eff
+}
+
+// Matchup is the multiplier of one attacking type.
+type Matchup struct {
+	Type       string
+	Multiplier float64
+}
+
+// Defense sorts every attacking type by how it fares against a type
+// combination.
+type Defense struct {
+	// Weaknesses take more than normal damage, strongest first.
+	Weaknesses []Matchup
+	// Resistances take less than normal damage, weakest first.
+	Resistances []Matchup
+	Immunities  []string
+}
+
+// Defend works out the weaknesses, resistances and immunities of a Pokemon
+// with the given types.
+func (c *Chart) Defend(defend []string) Defense {
+	var d Defense
+	for _, attack := range c.types {
+		switch m := c.Effectiveness(attack, defend); {
+		case m == 0:
+			d.Immunities = append(d.Immunities, attack)
+		case m > 1:
+			d.Weaknesses = append(d.Weaknesses, Matchup{attack, m})
+		case m < 1:
+			d.Resistances = append(d.Resistances, Matchup{attack, m})
+		}
+	}
+	sort.SliceStable(d.Weaknesses, func(i, j int) bool { return d.Weaknesses[i].Multiplier > d.Weaknesses[j].Multiplier })
+	sort.SliceStable(d.Resistances, func(i, j int) bool { return d.Resistances[i].Multiplier < d.Resistances[j].Multiplier })
+	return d
+}
@@ -0,0 +1,78 @@
+package typechart
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/anegri01f01/pokegocli/internal/fixtures"
+	"github.com/anegri01f01/pokegocli/internal/pokeapi"
+)
+
+func fixtureChart(t *testing.T) *Chart {
+	t.Helper()
+	srv := fixtures.NewServer()
+	t.Cleanup(srv.Close)
+	client := pokeapi.NewClient(pokeapi.WithBaseURL(srv.BaseURL()))
+	ctx := context.Background()
+
+	list, err := client.ListAllTypes(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var types []pokeapi.Type
+	for _, res := range list {
+		typ, err := client.GetType(ctx, res.Name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		types = append(types, typ)
+	}
+	return New(types)
+}
+
+func TestEffectiveness(t *testing.T) {
+	chart := fixtureChart(t)
+	cases := []struct {
+		attack string
+		defend []string
+		want   float64
+	}{
+		{"electric", []string{"water", "flying"}, 4},
+		{"fire", []string{"water"}, 0.5},
+		{"grass", []string{"bug", "poison"}, 0.25},
+		{"ground", []string{"electric", "flying"}, 0},
+		{"fighting", []string{"normal", "ghost"}, 0},
+		{"ice", []string{"fire", "grass"}, 1},
+		{"normal", []string{"normal"}, 1},
+	}
+	for _, c := range cases {
+		if got := chart.Effectiveness(c.attack, c.defend); got != c.want {
+			t.Errorf("Effectiveness(%s, %v) = %v, want %v", c.attack, c.defend, got, c.want)
+		}
+	}
+}
+
+func TestDefend(t *testing.T) {
+	chart := fixtureChart(t)
+	types, err := chart.Parse("Fire/Flying")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := chart.Defend(types)
+	want := Defense{
+		Weaknesses:  []Matchup{{"rock", 4}, {"water", 2}, {"electric", 2}},
+		Resistances: []Matchup{{"bug", 0.25}, {"grass", 0.25}, {"fighting", 0.5}, {"steel", 0.5}, {"fire", 0.5}, {"fairy", 0.5}},
+		Immunities:  []string{"ground"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Defend(fire/flying) = %+v\nwant %+v", got, want)
+	}
+
+	for _, bad := range []string{"fire/flying/water", "wood", "fire/"} {
+		if _, err := chart.Parse(bad); err == nil {
+			t.Errorf("Parse(%q) should fail", bad)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+// Package xdg resolves the per-user directories the Pokedex stores its
+// files in, following the XDG base directory specification.
+package xdg
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// AppName is the subdirectory created under each base directory.
+const AppName = "gokedex"
+
+// DataHome returns $XDG_DATA_HOME/gokedex, defaulting to ~/.local/share.
+func DataHome() (string, error) {
+	return dir("XDG_DATA_HOME", ".local", "share")
+}
+
+// CacheHome returns $XDG_CACHE_HOME/gokedex, defaulting to ~/.cache.
+func CacheHome() (string, error) {
+	return dir("XDG_CACHE_HOME", ".cache")
+}
+
+func dir(env string, fallback ...string) (string, error) {
+	if base := os.Getenv(env); filepath.IsAbs(base) {
+		return filepath.Join(base, AppName), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(append(append([]string{home}, fallback...), AppName)...), nil
+}
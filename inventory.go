@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/anegri01f01/pokegocli/internal/capture"
+	"github.com/anegri01f01/pokegocli/internal/i18n"
+)
+
+// firstVisitFinds is how many times the player searches an area explored
+// for the first time; later visits find something one time in
+// revisitFindOdds.
+const (
+	firstVisitFinds = 2
+	revisitFindOdds = 4
+)
+
+// evolutionStones are found one time in stoneFindOdds instead of balls.
+// Using one evolves some Pokemon.
+var evolutionStones = []string{"fire-stone", "water-stone", "thunder-stone", "leaf-stone", "moon-stone"}
+
+const stoneFindOdds = 8
+
+func commandInventory(ctx context.Context, config *Config) (any, error) {
+	result := inventoryResult{Items: []itemCount{}, lang: language(config)}
+	for _, ball := range capture.Balls {
+		if n := config.Pokedex.Inventory[string(ball)]; n > 0 {
+			result.Items = append(result.Items, itemCount{Item: string(ball), Count: n, Description: ball.Description()})
+		}
+	}
+	for _, stone := range evolutionStones {
+		if n := config.Pokedex.Inventory[stone]; n > 0 {
+			result.Items = append(result.Items, itemCount{Item: stone, Count: n, Description: "evolves some pokemon, see evolution"})
+		}
+	}
+	return result, nil
+}
+
+// findItems rolls the balls and evolution stones picked up while
+// exploring an area and adds them to the inventory.
+func findItems(config *Config, area string) []itemCount {
+	searches := firstVisitFinds
+	if config.Pokedex.Explored[area] {
+		searches = 0
+		if config.Rand.Intn(revisitFindOdds) == 0 {
+			searches = 1
+		}
+	}
+	config.Pokedex.Explored[area] = true
+
+	found := []itemCount{}
+	for i := 0; i < searches; i++ {
+		var item string
+		n := 1
+		if config.Rand.Intn(stoneFindOdds) == 0 {
+			item = evolutionStones[config.Rand.Intn(len(evolutionStones))]
+		} else {
+			var ball capture.Ball
+			ball, n = capture.Find(config.Rand)
+			item = string(ball)
+		}
+		config.Pokedex.AddItem(item, n)
+		if j := slices.IndexFunc(found, func(f itemCount) bool { return f.Item == item }); j >= 0 {
+			found[j].Count += n
+		} else {
+			found = append(found, itemCount{Item: item, Count: n})
+		}
+	}
+	return found
+}
+
+// pickBall resolves the --ball option and checks that one is carried.
+func pickBall(config *Config) (capture.Ball, error) {
+	ball := capture.PokeBall
+	if name, ok := config.Flags["ball"]; ok {
+		var err error
+		if ball, err = capture.ParseBall(name); err != nil {
+			return "", &usageError{cmdRegistry["catch"], err.Error()}
+		}
+	}
+	if config.Pokedex.Inventory[string(ball)] <= 0 {
+		return "", errors.New("you have no " + i18n.Title(string(ball)) + "s left, explore to find more")
+	}
+	return ball, nil
+}
+
+// isWaterMethod reports whether the Dive Ball bonus applies to Pokemon
+// found with an encounter method.
+func isWaterMethod(method string) bool {
+	return method == "surf" || strings.HasSuffix(method, "-rod")
+}
+
+// isDark reports whether the Dusk Ball bonus applies: at night, or in a
+// cave.
+func isDark(area string, now time.Time) bool {
+	return isNight(now) || strings.Contains(area, "cave")
+}
+
+// isNight reports whether it is night, for the Dusk Ball and evolutions.
+func isNight(now time.Time) bool {
+	return now.Hour() < 6 || now.Hour() >= 20
+}
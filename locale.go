@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"strings"
+
+	"github.com/anegri01f01/pokegocli/internal/i18n"
+	"github.com/anegri01f01/pokegocli/internal/pokeapi"
+)
+
+// language is the language names and messages are shown in: the running
+// command's --lang, or else the session's.
+func language(config *Config) string {
+	if lang, ok := config.Flags["lang"]; ok {
+		return strings.ToLower(lang)
+	}
+	return sessionLanguage(config)
+}
+
+// sessionLanguage is the lang variable, set with set lang or the --lang
+// option at startup, or English.
+func sessionLanguage(config *Config) string {
+	if lang := config.Vars["lang"]; lang != "" {
+		return strings.ToLower(lang)
+	}
+	return i18n.English
+}
+
+// namer collects the localized names of the PokeAPI resources a command
+// shows. In English the slugs are shown and nothing is fetched.
+type namer struct {
+	ctx    context.Context
+	config *Config
+	lang   string
+	labels labels
+}
+
+func newNamer(ctx context.Context, config *Config) *namer {
+	return &namer{ctx: ctx, config: config, lang: language(config)}
+}
+
+func (n *namer) english() bool {
+	return n.lang == i18n.English
+}
+
+func (n *namer) add(resource, slug string, names []pokeapi.Name) {
+	name := pokeapi.Localized(names, n.lang)
+	if name == "" {
+		return
+	}
+	if n.labels == nil {
+		n.labels = make(labels)
+	}
+	n.labels[resource+"/"+slug] = name
+}
+
+func (n *namer) area(name string) error {
+	if n.english() {
+		return nil
+	}
+	area, err := n.config.Client.GetLocationArea(n.ctx, name)
+	if err != nil {
+		return err
+	}
+	n.add("location-area", name, area.Names)
+	return nil
+}
+
+// pokemon names a Pokemon after its species.
+func (n *namer) pokemon(name string) error {
+	if n.english() {
+		return nil
+	}
+	data, ok := n.config.Pokedex.Pokemon[name]
+	if !ok {
+		var err error
+		if data, err = n.config.Client.GetPokemon(n.ctx, name); err != nil {
+			return err
+		}
+	}
+	species, err := n.config.Client.GetPokemonSpecies(n.ctx, data.Species.Name)
+	if err != nil {
+		return err
+	}
+	n.add("pokemon", name, species.Names)
+	return nil
+}
+
+func (n *namer) typ(name string) error {
+	if n.english() {
+		return nil
+	}
+	t, err := n.config.Client.GetType(n.ctx, name)
+	if err != nil {
+		return err
+	}
+	n.add("type", name, t.Names)
+	return nil
+}
+
+func (n *namer) stat(name string) error {
+	if n.english() {
+		return nil
+	}
+	stat, err := n.config.Client.GetStat(n.ctx, name)
+	if err != nil {
+		return err
+	}
+	n.add("stat", name, stat.Names)
+	return nil
+}
+
+func (n *namer) ability(name string) error {
+	if n.english() {
+		return nil
+	}
+	ability, err := n.config.Client.GetAbility(n.ctx, name)
+	if err != nil {
+		return err
+	}
+	n.add("ability", name, ability.Names)
+	return nil
+}
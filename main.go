@@ -1,5 +1,147 @@
-package main
-
-func main() {
-	repl()
-}
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/anegri01f01/pokegocli/internal/diskcache"
+	"github.com/anegri01f01/pokegocli/internal/fixtures"
+	"github.com/anegri01f01/pokegocli/internal/i18n"
+	"github.com/anegri01f01/pokegocli/internal/pokeapi"
+	"github.com/anegri01f01/pokegocli/internal/pokedex"
+	"github.com/anegri01f01/pokegocli/internal/render"
+	"github.com/anegri01f01/pokegocli/internal/xdg"
+)
+
+func main() {
+	apiBase := flag.String("api-base", envOr("POKEAPI_BASE", pokeapi.DefaultBaseURL), "PokeAPI base URL (env POKEAPI_BASE)")
+	useFixtures := flag.Bool("fixtures", false, "serve the bundled offline fixtures instead of PokeAPI")
+	pokedexPath := flag.String("pokedex", "", "path of the Pokedex file (default $XDG_DATA_HOME/gokedex/pokedex.json)")
+	cacheDir := flag.String("cache-dir", "", "directory of the on-disk response cache (default $XDG_CACHE_HOME/gokedex/http)")
+	cacheSize := flag.Int64("cache-size", 512, "maximum size of the on-disk response cache in MiB, 0 to disable it")
+	offline := flag.Bool("offline", false, "never touch the network, answer only from the cache")
+	timeout := flag.Duration("timeout", 15*time.Second, "give up on a PokeAPI request after this long, retries included")
+	retries := flag.Int("retries", 3, "retry rate limited and failed PokeAPI requests this many times")
+	rate := flag.Float64("rate", 10, "maximum PokeAPI requests per second, 0 for unlimited")
+	burst := flag.Int("burst", 10, "number of PokeAPI requests allowed in a burst above --rate")
+	seed := flag.Int64("seed", 0, "seed for catches and other random outcomes, 0 for a random seed")
+	output := flag.String("output", string(render.Text), "print command results as text, table, json or yaml")
+	flag.StringVar(output, "o", string(render.Text), "shorthand for --output")
+	lang := flag.String("lang", envOr("GOKEDEX_LANG", i18n.English), "show names and messages in this language, e.g. ja, fr or de (env GOKEDEX_LANG)")
+	flag.Usage = usage
+	flag.Parse()
+
+	words := flag.Args()
+	if len(words) > 0 {
+		var err error
+		if words, err = hoistGlobalFlags(flag.CommandLine, words); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			flag.Usage()
+			os.Exit(exitUsage)
+		}
+	}
+
+	format, err := render.ParseFormat(*output)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(exitUsage)
+	}
+
+	if *pokedexPath == "" {
+		path, err := pokedex.DefaultPath()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Could not locate the Pokedex file:", err)
+			os.Exit(exitError)
+		}
+		*pokedexPath = path
+	}
+	dex, err := pokedex.Load(*pokedexPath)
+	if errors.Is(err, pokedex.ErrCorrupt) {
+		fmt.Fprintln(os.Stderr, "Warning:", err)
+	} else if err != nil {
+		fmt.Fprintln(os.Stderr, "Could not load the Pokedex:", err)
+		os.Exit(exitError)
+	}
+
+	var srv *fixtures.Server
+	if *useFixtures {
+		srv = fixtures.NewServer()
+		*apiBase = srv.BaseURL()
+		fmt.Fprintln(os.Stderr, "Using offline fixtures at "+*apiBase)
+	}
+
+	clientOpts := []pokeapi.Option{
+		pokeapi.WithBaseURL(*apiBase),
+		pokeapi.WithCacheInterval(time.Minute * 5),
+		pokeapi.WithOffline(*offline),
+		pokeapi.WithTimeout(*timeout),
+		pokeapi.WithRetries(*retries),
+	}
+	if *rate > 0 {
+		clientOpts = append(clientOpts, pokeapi.WithRateLimit(*rate, *burst))
+	}
+	// The fixture server listens on a new port every run, so its responses
+	// are never worth keeping on disk.
+	if *cacheSize > 0 && !*useFixtures {
+		disk, err := openDiskCache(*cacheDir, *cacheSize<<20)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Warning: on-disk cache disabled:", err)
+		} else {
+			clientOpts = append(clientOpts, pokeapi.WithDiskCache(disk))
+		}
+	}
+
+	conf := &Config{
+		Client:      pokeapi.NewClient(clientOpts...),
+		Pokedex:     dex,
+		PokedexPath: *pokedexPath,
+		Output:      format,
+		Vars:        map[string]string{"lang": *lang},
+		Rand:        newRand(*seed),
+	}
+
+	code := exitOK
+	if len(words) > 0 {
+		code = runOnce(conf, words)
+	} else {
+		repl(conf)
+	}
+	if srv != nil {
+		srv.Close()
+	}
+	os.Exit(code)
+}
+
+// openDiskCache opens the on-disk response cache, defaulting to the XDG
+// cache directory.
+func openDiskCache(dir string, maxBytes int64) (*diskcache.Cache, error) {
+	if dir == "" {
+		base, err := xdg.CacheHome()
+		if err != nil {
+			return nil, err
+		}
+		dir = filepath.Join(base, "http")
+	}
+	return diskcache.Open(dir, maxBytes)
+}
+
+// newRand returns the session's random source. A zero seed picks one from
+// the clock.
+func newRand(seed int64) *rand.Rand {
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return rand.New(rand.NewSource(seed))
+}
+
+func envOr(key, fallback string) string {
+	if val, ok := os.LookupEnv(key); ok && val != "" {
+		return val
+	}
+	return fallback
+}
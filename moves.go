@@ -0,0 +1,192 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/anegri01f01/pokegocli/internal/i18n"
+	"github.com/anegri01f01/pokegocli/internal/pokeapi"
+)
+
+// learnMethods are the ways of learning a move moves can filter by, in the
+// order they are listed.
+var learnMethods = []string{"level-up", "machine", "egg", "tutor"}
+
+func commandMoves(ctx context.Context, config *Config) (any, error) {
+	var data pokeapi.Pokemon
+	if _, err := parseID(config.Args[0]); err == nil {
+		caught, err := caughtByID(config, config.Args[0])
+		if err != nil {
+			return nil, err
+		}
+		data = config.Pokedex.Pokemon[caught.Name]
+	} else {
+		name := strings.ToLower(config.Args[0])
+		var ok bool
+		if data, ok = config.Pokedex.Pokemon[name]; !ok {
+			if data, err = config.Client.GetPokemon(ctx, name); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	method, filtered := config.Flags["method"]
+	method = strings.ToLower(method)
+	if filtered && !slices.Contains(learnMethods, method) {
+		return nil, &usageError{cmdRegistry["moves"], i18n.Sprintf(language(config), "unknown learn method %s, want one of %s", method, strings.Join(learnMethods, ", "))}
+	}
+	groups := versionGroups(data)
+	if len(groups) == 0 {
+		return nil, errors.New(data.Name + " learns no moves")
+	}
+	group := groups[len(groups)-1]
+	if val, ok := config.Flags["version-group"]; ok {
+		group = strings.ToLower(val)
+		if !slices.Contains(groups, group) {
+			return nil, &usageError{cmdRegistry["moves"], i18n.Sprintf(language(config), "%s has no moves in %s, only in %s", data.Name, group, strings.Join(groups, ", "))}
+		}
+	}
+
+	type learned struct {
+		move, method string
+		level        int
+	}
+	var learnset []learned
+	var moveNames []string
+	for _, m := range data.Moves {
+		for _, vgd := range m.VersionGroupDetails {
+			if vgd.VersionGroup.Name != group || (filtered && vgd.MoveLearnMethod.Name != method) {
+				continue
+			}
+			learnset = append(learnset, learned{m.Move.Name, vgd.MoveLearnMethod.Name, vgd.LevelLearnedAt})
+			if !slices.Contains(moveNames, m.Move.Name) {
+				moveNames = append(moveNames, m.Move.Name)
+			}
+		}
+	}
+
+	// Moves are fetched once each, a few at a time.
+	var mu sync.Mutex
+	moves := make(map[string]pokeapi.Move, len(moveNames))
+	errs := fetchAll(ctx, moveNames, func(name string) error {
+		move, err := config.Client.GetMove(ctx, name)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		moves[name] = move
+		mu.Unlock()
+		return nil
+	}, nil)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	for _, name := range moveNames {
+		if err, ok := errs[name]; ok {
+			return nil, err
+		}
+	}
+
+	names := newNamer(ctx, config)
+	result := movesResult{Pokemon: data.Name, VersionGroup: group, Method: method, Moves: []learnedMove{}, lang: names.lang}
+	for _, l := range learnset {
+		move := moves[l.move]
+		names.add("move", move.Name, move.Names)
+		result.Moves = append(result.Moves, learnedMove{
+			Move:     move.Name,
+			Method:   l.method,
+			Level:    l.level,
+			Type:     move.Type.Name,
+			Class:    move.DamageClass.Name,
+			Power:    move.Power,
+			Accuracy: move.Accuracy,
+			PP:       move.PP,
+		})
+	}
+	sortLearnset(result.Moves)
+	if !names.english() {
+		result.Labels = names.labels
+	}
+	return result, nil
+}
+
+// versionGroups lists the version groups a Pokemon learns moves in, in
+// the order PokeAPI first mentions them, which is oldest first.
+func versionGroups(data pokeapi.Pokemon) []string {
+	var groups []string
+	for _, m := range data.Moves {
+		for _, vgd := range m.VersionGroupDetails {
+			if !slices.Contains(groups, vgd.VersionGroup.Name) {
+				groups = append(groups, vgd.VersionGroup.Name)
+			}
+		}
+	}
+	return groups
+}
+
+// sortLearnset puts the moves learned by leveling up first, by level,
+// and then the others by learn method and name.
+func sortLearnset(moves []learnedMove) {
+	rank := func(method string) int {
+		if i := slices.Index(learnMethods, method); i >= 0 {
+			return i
+		}
+		return len(learnMethods)
+	}
+	sort.SliceStable(moves, func(i, j int) bool {
+		a, b := moves[i], moves[j]
+		if rank(a.Method) != rank(b.Method) {
+			return rank(a.Method) < rank(b.Method)
+		}
+		if a.Method == "level-up" {
+			return a.Level < b.Level
+		}
+		return a.Move < b.Move
+	})
+}
+
+func commandMove(ctx context.Context, config *Config) (any, error) {
+	move, err := config.Client.GetMove(ctx, strings.ToLower(config.Args[0]))
+	if err != nil {
+		return nil, err
+	}
+	names := newNamer(ctx, config)
+	result := moveResult{
+		Name:         move.Name,
+		Type:         move.Type.Name,
+		Class:        move.DamageClass.Name,
+		Power:        move.Power,
+		Accuracy:     move.Accuracy,
+		PP:           move.PP,
+		Priority:     move.Priority,
+		EffectChance: move.EffectChance,
+		lang:         names.lang,
+	}
+	// Effects are written with a $effect_chance placeholder.
+	chance := ""
+	if move.EffectChance != nil {
+		chance = strconv.Itoa(*move.EffectChance)
+	}
+effect:
+	for _, l := range []string{names.lang, i18n.English} {
+		for _, e := range move.EffectEntries {
+			if e.Language.Name == l {
+				result.Effect = strings.ReplaceAll(e.Effect, "$effect_chance", chance)
+				break effect
+			}
+		}
+	}
+	if !names.english() {
+		names.add("move", move.Name, move.Names)
+		if err := names.typ(move.Type.Name); err != nil {
+			return nil, err
+		}
+		result.Labels = names.labels
+	}
+	return result, nil
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/anegri01f01/pokegocli/internal/i18n"
+	"github.com/anegri01f01/pokegocli/internal/pokeapi"
+)
+
+func TestVersionGroups(t *testing.T) {
+	cases := []struct {
+		name string
+		data string
+		want []string
+	}{
+		{"no moves", `{"moves": []}`, nil},
+		{"one group", `{"moves": [
+			{"move": {"name": "growl"}, "version_group_details": [{"version_group": {"name": "red-blue"}}]},
+			{"move": {"name": "thunder-shock"}, "version_group_details": [{"version_group": {"name": "red-blue"}}]}
+		]}`, []string{"red-blue"}},
+		{"first mention order", `{"moves": [
+			{"move": {"name": "growl"}, "version_group_details": [
+				{"version_group": {"name": "red-blue"}}, {"version_group": {"name": "diamond-pearl"}}
+			]},
+			{"move": {"name": "surf"}, "version_group_details": [
+				{"version_group": {"name": "gold-silver"}}, {"version_group": {"name": "red-blue"}}
+			]}
+		]}`, []string{"red-blue", "diamond-pearl", "gold-silver"}},
+	}
+	for _, c := range cases {
+		if got := versionGroups(decode[pokeapi.Pokemon](t, c.data)); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: versionGroups = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestSortLearnset(t *testing.T) {
+	cases := []struct {
+		name  string
+		moves []learnedMove
+		want  []string
+	}{
+		{
+			name: "levels",
+			moves: []learnedMove{
+				{Move: "thunderbolt", Method: "level-up", Level: 26},
+				{Move: "growl", Method: "level-up", Level: 1},
+				{Move: "quick-attack", Method: "level-up", Level: 13},
+			},
+			want: []string{"growl", "quick-attack", "thunderbolt"},
+		},
+		{
+			name: "ties keep their order",
+			moves: []learnedMove{
+				{Move: "thunder-shock", Method: "level-up", Level: 1},
+				{Move: "growl", Method: "level-up", Level: 1},
+				{Move: "tail-whip", Method: "level-up", Level: 1},
+			},
+			want: []string{"thunder-shock", "growl", "tail-whip"},
+		},
+		{
+			name: "methods",
+			moves: []learnedMove{
+				{Move: "wish", Method: "egg"},
+				{Move: "volt-tackle", Method: "light-ball-egg"},
+				{Move: "thunder-wave", Method: "machine"},
+				{Move: "iron-tail", Method: "machine"},
+				{Move: "surf", Method: "tutor"},
+				{Move: "thunderbolt", Method: "level-up", Level: 26},
+				{Move: "present", Method: "egg"},
+			},
+			want: []string{"thunderbolt", "iron-tail", "thunder-wave", "present", "wish", "surf", "volt-tackle"},
+		},
+	}
+	for _, c := range cases {
+		sortLearnset(c.moves)
+		var got []string
+		for _, m := range c.moves {
+			got = append(got, m.Move)
+		}
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: sortLearnset = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestMovesFetchesEachMoveOnce(t *testing.T) {
+	conf := newFixtureConfig(t)
+	conf.Pokedex.Pokemon["pikachu"] = decode[pokeapi.Pokemon](t, `{"name": "pikachu", "moves": [
+		{"move": {"name": "growl"}, "version_group_details": [
+			{"level_learned_at": 1, "move_learn_method": {"name": "level-up"}, "version_group": {"name": "diamond-pearl"}}
+		]},
+		{"move": {"name": "thunderbolt"}, "version_group_details": [
+			{"level_learned_at": 26, "move_learn_method": {"name": "level-up"}, "version_group": {"name": "diamond-pearl"}},
+			{"level_learned_at": 0, "move_learn_method": {"name": "machine"}, "version_group": {"name": "diamond-pearl"}},
+			{"level_learned_at": 0, "move_learn_method": {"name": "machine"}, "version_group": {"name": "red-blue"}}
+		]}
+	]}`)
+
+	var result movesResult
+	out := captureOutput(t, func() error {
+		return runLine(context.Background(), conf, "moves pikachu --version-group diamond-pearl -o json")
+	})
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("moves -o json is not JSON: %v\n%s", err, out)
+	}
+	var got []string
+	for _, m := range result.Moves {
+		got = append(got, m.Move+" "+m.learned())
+	}
+	want := []string{"growl Lv. 1", "thunderbolt Lv. 26", "thunderbolt machine"}
+	if !reflect.DeepEqual(got, want) || result.Moves[2].Type != "electric" {
+		t.Errorf("moves = %v, want %v", got, want)
+	}
+	if stats := conf.Client.CacheStats(); stats.Fetches != 2 || stats.MemoryHits != 0 {
+		t.Errorf("expected one fetch per move, got %+v", stats)
+	}
+}
+
+func TestMovesTextWithoutMoves(t *testing.T) {
+	cases := map[string]string{
+		"":        "ditto learns no moves in red-blue\n",
+		"machine": "ditto learns no machine moves in red-blue\n",
+	}
+	for method, want := range cases {
+		var out strings.Builder
+		result := movesResult{Pokemon: "ditto", VersionGroup: "red-blue", Method: method, lang: i18n.English}
+		if err := result.Text(&out); err != nil || out.String() != want {
+			t.Errorf("Text with method %q = %q, %v, want %q", method, out.String(), err, want)
+		}
+	}
+}
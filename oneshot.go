@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+)
+
+// Exit codes of one-shot mode.
+const (
+	exitOK        = 0
+	exitError     = 1
+	exitUsage     = 2
+	exitCancelled = 130
+)
+
+var errUnknownCommand = errors.New("unknown command")
+
+// runOnce runs a single command given on the command line, e.g.
+// `gokedex explore canalave-city-area`, and returns the exit code.
+func runOnce(conf *Config, words []string) int {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	err := runCommand(ctx, conf, words)
+	if errors.Is(err, errExit) {
+		err = nil
+	}
+	if err == nil {
+		err = conf.Pokedex.Save(conf.PokedexPath)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, describeError(conf, err))
+	}
+	return exitCode(err)
+}
+
+func exitCode(err error) int {
+	var usageErr *usageError
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.Is(err, context.Canceled):
+		return exitCancelled
+	case errors.As(err, &usageErr), errors.Is(err, errUnknownCommand):
+		return exitUsage
+	}
+	return exitError
+}
+
+// hoistGlobalFlags applies global flags written after the command name,
+// as in `gokedex catch pikachu --offline`, to fs and returns the remaining
+// words. A command's own options take precedence, and nothing after "--"
+// is touched.
+func hoistGlobalFlags(fs *flag.FlagSet, words []string) ([]string, error) {
+	cmd := cmdRegistry[strings.ToLower(words[0])]
+	rest := []string{words[0]}
+
+	for i := 1; i < len(words); i++ {
+		word := words[i]
+		if word == "--" {
+			rest = append(rest, words[i:]...)
+			break
+		}
+		if !strings.HasPrefix(word, "-") || word == "-" {
+			rest = append(rest, word)
+			continue
+		}
+
+		name, value, hasValue := strings.Cut(strings.TrimLeft(word, "-"), "=")
+		if _, ok := cmd.flag(name); ok {
+			rest = append(rest, word)
+			continue
+		}
+		global := fs.Lookup(name)
+		if global == nil {
+			rest = append(rest, word)
+			continue
+		}
+		if !hasValue {
+			if b, ok := global.Value.(interface{ IsBoolFlag() bool }); ok && b.IsBoolFlag() {
+				value = "true"
+			} else if i+1 < len(words) {
+				i++
+				value = words[i]
+			} else {
+				return nil, fmt.Errorf("flag needs an argument: -%s", name)
+			}
+		}
+		if err := fs.Set(name, value); err != nil {
+			return nil, fmt.Errorf("invalid value %q for flag -%s: %v", value, name, err)
+		}
+	}
+	return rest, nil
+}
+
+func usage() {
+	out := flag.CommandLine.Output()
+	fmt.Fprintf(out, "Usage: %s [options] [command [arguments]]\n\n", os.Args[0])
+	fmt.Fprintln(out, "Without a command the interactive Pokedex starts. Commands:")
+	for _, cmd := range sortedCommands() {
+		fmt.Fprintf(out, "  %s\n", cmd.usage())
+	}
+	fmt.Fprintln(out, "\nOptions:")
+	flag.PrintDefaults()
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"io"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestHoistGlobalFlags(t *testing.T) {
+	fs := flag.NewFlagSet("gokedex", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	offline := fs.Bool("offline", false, "")
+	timeout := fs.Duration("timeout", time.Second, "")
+
+	words, err := hoistGlobalFlags(fs, []string{"catch", "pikachu", "--offline", "-timeout", "3s", "--", "--timeout=9s"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"catch", "pikachu", "--", "--timeout=9s"}; !reflect.DeepEqual(words, want) {
+		t.Errorf("got %q, want %q", words, want)
+	}
+	if !*offline || *timeout != 3*time.Second {
+		t.Errorf("flags not applied: offline=%v timeout=%v", *offline, *timeout)
+	}
+
+	if _, err := hoistGlobalFlags(fs, []string{"map", "--timeout"}); err == nil {
+		t.Error("expected an error for a flag without its value")
+	}
+	if _, err := hoistGlobalFlags(fs, []string{"map", "--timeout=soon"}); err == nil {
+		t.Error("expected an error for an invalid value")
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	cases := []struct {
+		err  error
+		code int
+	}{
+		{nil, exitOK},
+		{errors.New("boom"), exitError},
+		{&usageError{cliCommand{name: "explore"}, "missing area"}, exitUsage},
+		{errUnknownCommand, exitUsage},
+		{context.Canceled, exitCancelled},
+	}
+	for _, c := range cases {
+		if got := exitCode(c.err); got != c.code {
+			t.Errorf("exitCode(%v) = %d, want %d", c.err, got, c.code)
+		}
+	}
+}
+
+func TestExitInOneShotMode(t *testing.T) {
+	conf := newFixtureConfig(t)
+	conf.Pokedex.AddItem("master-ball", 1)
+	if code := runOnce(conf, []string{"exit"}); code != exitOK {
+		t.Errorf("exit returned %d, want %d", code, exitOK)
+	}
+	if _, err := os.Stat(conf.PokedexPath); err != nil {
+		t.Errorf("exit did not save the pokedex: %v", err)
+	}
+}
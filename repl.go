@@ -1,671 +1,958 @@
-package main
-
-import (
-	"bufio"
-	"encoding/json"
-	"errors"
-	"fmt"
-	"io"
-	"net/http"
-	"os"
-	"strings"
-	"time"
-	"math/rand"
-    "strconv"
-
-	"github.com/anegri01f01/pokegocli/internal/pokecache"
-)
-
-type Config struct {
-	Next     	string
-	Previous 	string
-	PokemonInfo string
-	Cache    	pokecache.Cache
-	Args     	string
-}
-
-type cliCommand struct {
-	name        string
-	description string
-	callback    func(config *Config) error
-}
-
-type (
-	Locations struct {
-		Count    int
-		Next     string
-		Previous string
-		Results  []Result
-	}
-
-	Result struct {
-		Name string
-		Url  string
-	}
-)
-
-type LocationArea struct {
-	EncounterMethodRates []struct {
-		EncounterMethod struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"encounter_method"`
-		VersionDetails []struct {
-			Rate    int `json:"rate"`
-			Version struct {
-				Name string `json:"name"`
-				URL  string `json:"url"`
-			} `json:"version"`
-		} `json:"version_details"`
-	} `json:"encounter_method_rates"`
-	GameIndex int `json:"game_index"`
-	ID        int `json:"id"`
-	Location  struct {
-		Name string `json:"name"`
-		URL  string `json:"url"`
-	} `json:"location"`
-	Name  string `json:"name"`
-	Names []struct {
-		Language struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"language"`
-		Name string `json:"name"`
-	} `json:"names"`
-	PokemonEncounters []struct {
-		Pokemon struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"pokemon"`
-		VersionDetails []struct {
-			EncounterDetails []struct {
-				Chance          int           `json:"chance"`
-				ConditionValues []interface{} `json:"condition_values"`
-				MaxLevel        int           `json:"max_level"`
-				Method          struct {
-					Name string `json:"name"`
-					URL  string `json:"url"`
-				} `json:"method"`
-				MinLevel int `json:"min_level"`
-			} `json:"encounter_details"`
-			MaxChance int `json:"max_chance"`
-			Version   struct {
-				Name string `json:"name"`
-				URL  string `json:"url"`
-			} `json:"version"`
-		} `json:"version_details"`
-	} `json:"pokemon_encounters"`
-}
-
-type Pokemon struct {
-	Abilities []struct {
-		Ability struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"ability"`
-		IsHidden bool `json:"is_hidden"`
-		Slot     int  `json:"slot"`
-	} `json:"abilities"`
-	BaseExperience int `json:"base_experience"`
-	Cries          struct {
-		Latest string `json:"latest"`
-		Legacy string `json:"legacy"`
-	} `json:"cries"`
-	Forms []struct {
-		Name string `json:"name"`
-		URL  string `json:"url"`
-	} `json:"forms"`
-	GameIndices []struct {
-		GameIndex int `json:"game_index"`
-		Version   struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"version"`
-	} `json:"game_indices"`
-	Height    int `json:"height"`
-	HeldItems []struct {
-		Item struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"item"`
-		VersionDetails []struct {
-			Rarity  int `json:"rarity"`
-			Version struct {
-				Name string `json:"name"`
-				URL  string `json:"url"`
-			} `json:"version"`
-		} `json:"version_details"`
-	} `json:"held_items"`
-	ID                     int    `json:"id"`
-	IsDefault              bool   `json:"is_default"`
-	LocationAreaEncounters string `json:"location_area_encounters"`
-	Moves                  []struct {
-		Move struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"move"`
-		VersionGroupDetails []struct {
-			LevelLearnedAt  int `json:"level_learned_at"`
-			MoveLearnMethod struct {
-				Name string `json:"name"`
-				URL  string `json:"url"`
-			} `json:"move_learn_method"`
-			Order        interface{} `json:"order"`
-			VersionGroup struct {
-				Name string `json:"name"`
-				URL  string `json:"url"`
-			} `json:"version_group"`
-		} `json:"version_group_details"`
-	} `json:"moves"`
-	Name          string `json:"name"`
-	Order         int    `json:"order"`
-	PastAbilities []struct {
-		Abilities []struct {
-			Ability  interface{} `json:"ability"`
-			IsHidden bool        `json:"is_hidden"`
-			Slot     int         `json:"slot"`
-		} `json:"abilities"`
-		Generation struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"generation"`
-	} `json:"past_abilities"`
-	PastTypes []interface{} `json:"past_types"`
-	Species   struct {
-		Name string `json:"name"`
-		URL  string `json:"url"`
-	} `json:"species"`
-	Sprites struct {
-		BackDefault      string      `json:"back_default"`
-		BackFemale       interface{} `json:"back_female"`
-		BackShiny        string      `json:"back_shiny"`
-		BackShinyFemale  interface{} `json:"back_shiny_female"`
-		FrontDefault     string      `json:"front_default"`
-		FrontFemale      interface{} `json:"front_female"`
-		FrontShiny       string      `json:"front_shiny"`
-		FrontShinyFemale interface{} `json:"front_shiny_female"`
-		Other            struct {
-			DreamWorld struct {
-				FrontDefault string      `json:"front_default"`
-				FrontFemale  interface{} `json:"front_female"`
-			} `json:"dream_world"`
-			Home struct {
-				FrontDefault     string      `json:"front_default"`
-				FrontFemale      interface{} `json:"front_female"`
-				FrontShiny       string      `json:"front_shiny"`
-				FrontShinyFemale interface{} `json:"front_shiny_female"`
-			} `json:"home"`
-			OfficialArtwork struct {
-				FrontDefault string `json:"front_default"`
-				FrontShiny   string `json:"front_shiny"`
-			} `json:"official-artwork"`
-			Showdown struct {
-				BackDefault      string      `json:"back_default"`
-				BackFemale       interface{} `json:"back_female"`
-				BackShiny        string      `json:"back_shiny"`
-				BackShinyFemale  interface{} `json:"back_shiny_female"`
-				FrontDefault     string      `json:"front_default"`
-				FrontFemale      interface{} `json:"front_female"`
-				FrontShiny       string      `json:"front_shiny"`
-				FrontShinyFemale interface{} `json:"front_shiny_female"`
-			} `json:"showdown"`
-		} `json:"other"`
-		Versions struct {
-			GenerationI struct {
-				RedBlue struct {
-					BackDefault      string `json:"back_default"`
-					BackGray         string `json:"back_gray"`
-					BackTransparent  string `json:"back_transparent"`
-					FrontDefault     string `json:"front_default"`
-					FrontGray        string `json:"front_gray"`
-					FrontTransparent string `json:"front_transparent"`
-				} `json:"red-blue"`
-				Yellow struct {
-					BackDefault      string `json:"back_default"`
-					BackGray         string `json:"back_gray"`
-					BackTransparent  string `json:"back_transparent"`
-					FrontDefault     string `json:"front_default"`
-					FrontGray        string `json:"front_gray"`
-					FrontTransparent string `json:"front_transparent"`
-				} `json:"yellow"`
-			} `json:"generation-i"`
-			GenerationIi struct {
-				Crystal struct {
-					BackDefault           string `json:"back_default"`
-					BackShiny             string `json:"back_shiny"`
-					BackShinyTransparent  string `json:"back_shiny_transparent"`
-					BackTransparent       string `json:"back_transparent"`
-					FrontDefault          string `json:"front_default"`
-					FrontShiny            string `json:"front_shiny"`
-					FrontShinyTransparent string `json:"front_shiny_transparent"`
-					FrontTransparent      string `json:"front_transparent"`
-				} `json:"crystal"`
-				Gold struct {
-					BackDefault      string `json:"back_default"`
-					BackShiny        string `json:"back_shiny"`
-					FrontDefault     string `json:"front_default"`
-					FrontShiny       string `json:"front_shiny"`
-					FrontTransparent string `json:"front_transparent"`
-				} `json:"gold"`
-				Silver struct {
-					BackDefault      string `json:"back_default"`
-					BackShiny        string `json:"back_shiny"`
-					FrontDefault     string `json:"front_default"`
-					FrontShiny       string `json:"front_shiny"`
-					FrontTransparent string `json:"front_transparent"`
-				} `json:"silver"`
-			} `json:"generation-ii"`
-			GenerationIii struct {
-				Emerald struct {
-					FrontDefault string `json:"front_default"`
-					FrontShiny   string `json:"front_shiny"`
-				} `json:"emerald"`
-				FireredLeafgreen struct {
-					BackDefault  string `json:"back_default"`
-					BackShiny    string `json:"back_shiny"`
-					FrontDefault string `json:"front_default"`
-					FrontShiny   string `json:"front_shiny"`
-				} `json:"firered-leafgreen"`
-				RubySapphire struct {
-					BackDefault  string `json:"back_default"`
-					BackShiny    string `json:"back_shiny"`
-					FrontDefault string `json:"front_default"`
-					FrontShiny   string `json:"front_shiny"`
-				} `json:"ruby-sapphire"`
-			} `json:"generation-iii"`
-			GenerationIv struct {
-				DiamondPearl struct {
-					BackDefault      string      `json:"back_default"`
-					BackFemale       interface{} `json:"back_female"`
-					BackShiny        string      `json:"back_shiny"`
-					BackShinyFemale  interface{} `json:"back_shiny_female"`
-					FrontDefault     string      `json:"front_default"`
-					FrontFemale      interface{} `json:"front_female"`
-					FrontShiny       string      `json:"front_shiny"`
-					FrontShinyFemale interface{} `json:"front_shiny_female"`
-				} `json:"diamond-pearl"`
-				HeartgoldSoulsilver struct {
-					BackDefault      string      `json:"back_default"`
-					BackFemale       interface{} `json:"back_female"`
-					BackShiny        string      `json:"back_shiny"`
-					BackShinyFemale  interface{} `json:"back_shiny_female"`
-					FrontDefault     string      `json:"front_default"`
-					FrontFemale      interface{} `json:"front_female"`
-					FrontShiny       string      `json:"front_shiny"`
-					FrontShinyFemale interface{} `json:"front_shiny_female"`
-				} `json:"heartgold-soulsilver"`
-				Platinum struct {
-					BackDefault      string      `json:"back_default"`
-					BackFemale       interface{} `json:"back_female"`
-					BackShiny        string      `json:"back_shiny"`
-					BackShinyFemale  interface{} `json:"back_shiny_female"`
-					FrontDefault     string      `json:"front_default"`
-					FrontFemale      interface{} `json:"front_female"`
-					FrontShiny       string      `json:"front_shiny"`
-					FrontShinyFemale interface{} `json:"front_shiny_female"`
-				} `json:"platinum"`
-			} `json:"generation-iv"`
-			GenerationIx struct {
-				ScarletViolet struct {
-					FrontDefault string      `json:"front_default"`
-					FrontFemale  interface{} `json:"front_female"`
-				} `json:"scarlet-violet"`
-			} `json:"generation-ix"`
-			GenerationV struct {
-				BlackWhite struct {
-					Animated struct {
-						BackDefault      string      `json:"back_default"`
-						BackFemale       interface{} `json:"back_female"`
-						BackShiny        string      `json:"back_shiny"`
-						BackShinyFemale  interface{} `json:"back_shiny_female"`
-						FrontDefault     string      `json:"front_default"`
-						FrontFemale      interface{} `json:"front_female"`
-						FrontShiny       string      `json:"front_shiny"`
-						FrontShinyFemale interface{} `json:"front_shiny_female"`
-					} `json:"animated"`
-					BackDefault      string      `json:"back_default"`
-					BackFemale       interface{} `json:"back_female"`
-					BackShiny        string      `json:"back_shiny"`
-					BackShinyFemale  interface{} `json:"back_shiny_female"`
-					FrontDefault     string      `json:"front_default"`
-					FrontFemale      interface{} `json:"front_female"`
-					FrontShiny       string      `json:"front_shiny"`
-					FrontShinyFemale interface{} `json:"front_shiny_female"`
-				} `json:"black-white"`
-			} `json:"generation-v"`
-			GenerationVi struct {
-				OmegarubyAlphasapphire struct {
-					FrontDefault     string      `json:"front_default"`
-					FrontFemale      interface{} `json:"front_female"`
-					FrontShiny       string      `json:"front_shiny"`
-					FrontShinyFemale interface{} `json:"front_shiny_female"`
-				} `json:"omegaruby-alphasapphire"`
-				XY struct {
-					FrontDefault     string      `json:"front_default"`
-					FrontFemale      interface{} `json:"front_female"`
-					FrontShiny       string      `json:"front_shiny"`
-					FrontShinyFemale interface{} `json:"front_shiny_female"`
-				} `json:"x-y"`
-			} `json:"generation-vi"`
-			GenerationVii struct {
-				Icons struct {
-					FrontDefault string      `json:"front_default"`
-					FrontFemale  interface{} `json:"front_female"`
-				} `json:"icons"`
-				UltraSunUltraMoon struct {
-					FrontDefault     string      `json:"front_default"`
-					FrontFemale      interface{} `json:"front_female"`
-					FrontShiny       string      `json:"front_shiny"`
-					FrontShinyFemale interface{} `json:"front_shiny_female"`
-				} `json:"ultra-sun-ultra-moon"`
-			} `json:"generation-vii"`
-			GenerationViii struct {
-				BrilliantDiamondShiningPearl struct {
-					FrontDefault string      `json:"front_default"`
-					FrontFemale  interface{} `json:"front_female"`
-				} `json:"brilliant-diamond-shining-pearl"`
-				Icons struct {
-					FrontDefault string      `json:"front_default"`
-					FrontFemale  interface{} `json:"front_female"`
-				} `json:"icons"`
-			} `json:"generation-viii"`
-		} `json:"versions"`
-	} `json:"sprites"`
-	Stats []struct {
-		BaseStat int `json:"base_stat"`
-		Effort   int `json:"effort"`
-		Stat     struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"stat"`
-	} `json:"stats"`
-	Types []struct {
-		Slot int `json:"slot"`
-		Type struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"type"`
-	} `json:"types"`
-	Weight int `json:"weight"`
-}
-
-var cmdRegistry map[string]cliCommand
-
-var pokemonRegistry map[string]Pokemon
-
-func cleanInput(text string) []string {
-	str := strings.ToLower(text)
-	str = strings.ReplaceAll(str, "  ", " ")
-	str = strings.TrimSpace(str)
-	return strings.Split(str, " ")
-}
-
-func commandExit(config *Config) error {
-	fmt.Println("Closing the Pokedex... Goodbye!")
-	os.Exit(0)
-	return errors.New("Could not exit the application")
-}
-
-func commandHelp(config *Config) error {
-	fmt.Printf("Welcome to the Pokedex!\nUsage:\n\n")
-	for key, cmd := range cmdRegistry {
-		fmt.Printf("%s: %s\n", key, cmd.description)
-	}
-	return nil
-}
-
-func commandMap(config *Config) error {
-
-	var err error
-	var locations Locations
-
-	if val, ok := config.Cache.Get(config.Next); ok {
-		if err := json.Unmarshal(val, &locations); err != nil {
-			return err
-		}
-	} else {
-		res, err := http.Get(config.Next)
-		if err != nil {
-			return err
-		}
-		body, err := io.ReadAll(res.Body)
-
-		if err := json.Unmarshal(body, &locations); err != nil {
-			return err
-		}
-		defer res.Body.Close()
-		if res.StatusCode > 299 {
-			return nil
-		}
-		config.Cache.Add(config.Next, body)
-	}
-
-	for i := 0; i < len(locations.Results); i++ {
-		fmt.Println(locations.Results[i].Name)
-	}
-
-	config.Next = locations.Next
-	config.Previous = locations.Previous
-
-	return err
-}
-
-func commandMapb(config *Config) error {
-
-	var err error
-	var locations Locations
-
-	if val, ok := config.Cache.Get(config.Previous); ok {
-		if err := json.Unmarshal(val, &locations); err != nil {
-			return err
-		}
-	} else {
-		res, err := http.Get(config.Previous)
-		if err != nil {
-			return err
-		}
-		body, err := io.ReadAll(res.Body)
-
-		if err := json.Unmarshal(body, &locations); err != nil {
-			return err
-		}
-		defer res.Body.Close()
-		if res.StatusCode > 299 {
-			return nil
-		}
-		config.Cache.Add(config.Previous, body)
-	}
-
-	for i := 0; i < len(locations.Results); i++ {
-		fmt.Println(locations.Results[i].Name)
-	}
-
-	config.Next = locations.Next
-	config.Previous = locations.Previous
-
-	return err
-}
-
-func commandExplore(config *Config) error {
-	var err error
-	var locationArea LocationArea
-
-	var query string = config.Next + "/" + config.Args
-
-	if val, ok := config.Cache.Get(query); ok {
-		if err := json.Unmarshal(val, &locationArea); err != nil {
-			return err
-		}
-	} else {
-		res, err := http.Get(query)
-		if err != nil {
-			return err
-		}
-		body, err := io.ReadAll(res.Body)
-
-		if err := json.Unmarshal(body, &locationArea); err != nil {
-			return err
-		}
-		defer res.Body.Close()
-		if res.StatusCode > 299 {
-			return nil
-		}
-		config.Cache.Add(config.Next, body)
-	}
-
-	fmt.Println("Exploring " + config.Args + "...")
-	fmt.Println("Found Pokemon:")
-	for i := 0; i < len(locationArea.PokemonEncounters); i++ {
-		fmt.Println(" - " + locationArea.PokemonEncounters[i].Pokemon.Name)
-	}
-
-	return err
-}
-
-func commandCatch(config *Config) error {
-	var err error
-	var pokemon Pokemon
-
-	var query string = config.PokemonInfo + "/" + config.Args
-
-	if val, ok := config.Cache.Get(query); ok {
-		if err := json.Unmarshal(val, &pokemon); err != nil {
-			return err
-		}
-	} else {
-		res, err := http.Get(query)
-		if err != nil {
-			return err
-		}
-		body, err := io.ReadAll(res.Body)
-
-		if err := json.Unmarshal(body, &pokemon); err != nil {
-			return err
-		}
-		defer res.Body.Close()
-		if res.StatusCode > 299 {
-			return nil
-		}
-		config.Cache.Add(config.Next, body)
-	}
-
-	fmt.Println("Throwing a Pokeball at " + config.Args + "...")
-	
-	var catchTry int = rand.Intn(pokemon.BaseExperience)
-
-	if (catchTry <= 20) {
-		fmt.Println(config.Args + " was caught!")
-		pokemonRegistry[config.Args] = pokemon
-
-	} else {
-		fmt.Println(config.Args + " escaped!")
-	}
-	return err
-}
-
-func commandInspect(config *Config) error {
-	var err error
-
-	pokemon, ok := pokemonRegistry[config.Args]
-	
-	if ok {
-		fmt.Println("Name: " + config.Args)
-		fmt.Println("Height: " + strconv.Itoa(pokemon.Height))
-		fmt.Println("Weight: " + strconv.Itoa(pokemon.Weight))
-		fmt.Println("Stats:")
-		for i :=0; i < len(pokemon.Stats); i++ {
-			fmt.Println("  -" + pokemon.Stats[i].Stat.Name + ": " + strconv.Itoa(pokemon.Stats[i].BaseStat))
-		}
-		fmt.Println("Types:")
-		for i :=0; i < len(pokemon.Types); i++ {
-			fmt.Println("  - " + pokemon.Types[i].Type.Name)
-		}
-	}
-	return err
-}
-
-func commandPokedex(config *Config) error {
-	var err error
-	for name := range pokemonRegistry {
-		fmt.Println("  - ", name)
-	}	
-	return err
-}
-
-func repl() {
-
-	pokemonRegistry = make(map[string]Pokemon)
-
-	cmdRegistry = map[string]cliCommand{
-		"pokedex": {
-			name:        "pokedex",
-			description: "List all names of the pokemon the user has caught",
-			callback:    commandPokedex,
-		},
-		"inspect": {
-			name:        "inspect",
-			description: "Inspects a pokemon and displays its name, weight, stats, and type(s)",
-			callback:    commandInspect,
-		},
-		"catch": {
-			name:        "catch",
-			description: "Trys to catch a pokemon given the name",
-			callback:    commandCatch,
-		},
-		"explore": {
-			name:        "explore",
-			description: "Displays all pokeman in a given area",
-			callback:    commandExplore,
-		},
-		"map": {
-			name:        "map",
-			description: "Displays all areas",
-			callback:    commandMap,
-		},
-		"mapb": {
-			name:        "mapb",
-			description: "Displays all areas",
-			callback:    commandMapb,
-		},
-		"exit": {
-			name:        "exit",
-			description: "Exit the Pokedex",
-			callback:    commandExit,
-		},
-		"help": {
-			name:        "help",
-			description: "Displays a help message",
-			callback:    commandHelp,
-		},
-	}
-	scanner := bufio.NewScanner(os.Stdin)
-
-	fmt.Printf("Pokedex > ")
-
-	conf := Config{}
-	conf.PokemonInfo = "https://pokeapi.co/api/v2/pokemon/"
-	conf.Next = "https://pokeapi.co/api/v2/location-area"
-	conf.Cache = pokecache.NewCache(time.Minute * 5)
-
-	for scanner.Scan() {
-		line := scanner.Text()
-
-		args := cleanInput(line)
-
-		cmd, ok := cmdRegistry[args[0]]
-		if len(args) > 1 {
-			conf.Args = args[1]
-		}
-		if ok {
-			cmd.callback(&conf)
-		} else {
-			fmt.Println("Command does not exists")
-		}
-
-		fmt.Printf("Pokedex > ")
-	}
-
-	if err := scanner.Err(); err != nil {
-		fmt.Printf("Error during scanning: %v\n", err)
-	}
-}
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/anegri01f01/pokegocli/internal/battle"
+	"github.com/anegri01f01/pokegocli/internal/capture"
+	"github.com/anegri01f01/pokegocli/internal/encounter"
+	"github.com/anegri01f01/pokegocli/internal/i18n"
+	"github.com/anegri01f01/pokegocli/internal/lineedit"
+	"github.com/anegri01f01/pokegocli/internal/pokeapi"
+	"github.com/anegri01f01/pokegocli/internal/pokedex"
+	"github.com/anegri01f01/pokegocli/internal/render"
+	"github.com/anegri01f01/pokegocli/internal/stats"
+	"github.com/anegri01f01/pokegocli/internal/typechart"
+	"github.com/anegri01f01/pokegocli/internal/xdg"
+)
+
+type Config struct {
+	Client      *pokeapi.Client
+	Pokedex     *pokedex.Pokedex
+	PokedexPath string
+	Next        string
+	Previous    string
+	// LastAreas remembers the last map page for tab completion.
+	LastAreas []string
+	// Args and Flags hold the parsed arguments of the running command.
+	Args  []string
+	Flags map[string]string
+	// Vars are the variables defined with set, expanded as $name.
+	Vars map[string]string
+	// Rand drives every random outcome, so a seed replays a session.
+	Rand *rand.Rand
+	// Output is the default format for command results, overridden per
+	// command with --output.
+	Output render.Format
+	script *scriptState
+	// types is the type chart, loaded on first use.
+	types *typechart.Chart
+}
+
+type cliCommand struct {
+	name        string
+	description string
+	args        []argDef
+	flags       []flagDef
+	callback    func(ctx context.Context, config *Config) (any, error)
+}
+
+var cmdRegistry map[string]cliCommand
+
+var scriptFlags = []flagDef{
+	{name: "errexit", usage: "stop at the first failing command, like set -e"},
+	{name: "echo", usage: "print each command before running it, like set -x"},
+}
+
+// errExit asks the REPL, a script or one-shot mode to stop. They save the
+// Pokedex and return to main, which cleans up and exits.
+var errExit = errors.New("exit")
+
+func commandExit(ctx context.Context, config *Config) (any, error) {
+	return nil, errExit
+}
+
+func closePokedex(config *Config) error {
+	if err := config.Pokedex.Save(config.PokedexPath); err != nil {
+		return err
+	}
+	fmt.Println("Closing the Pokedex... Goodbye!")
+	return nil
+}
+
+func commandHelp(ctx context.Context, config *Config) (any, error) {
+	if len(config.Args) > 0 {
+		cmd, ok := cmdRegistry[strings.ToLower(config.Args[0])]
+		if !ok {
+			return nil, fmt.Errorf("%w %q", errUnknownCommand, config.Args[0])
+		}
+		return helpResult{Commands: []helpEntry{helpFor(cmd)}, Single: true, lang: language(config)}, nil
+	}
+
+	result := helpResult{lang: language(config)}
+	for _, cmd := range sortedCommands() {
+		result.Commands = append(result.Commands, helpFor(cmd))
+	}
+	return result, nil
+}
+
+func helpFor(cmd cliCommand) helpEntry {
+	entry := helpEntry{Name: cmd.name, Usage: cmd.usage(), Description: cmd.description}
+	for _, f := range cmd.flags {
+		entry.Options = append(entry.Options, optionHelp{Name: f.usageName(), Usage: f.usage})
+	}
+	return entry
+}
+
+func commandMap(ctx context.Context, config *Config) (any, error) {
+	if config.Next == "" && config.Previous != "" {
+		return messageResult{i18n.T(language(config), "you're on the last page")}, nil
+	}
+	return showLocationAreas(ctx, config, config.Next)
+}
+
+func commandMapb(ctx context.Context, config *Config) (any, error) {
+	if config.Previous == "" {
+		return messageResult{i18n.T(language(config), "you're on the first page")}, nil
+	}
+	return showLocationAreas(ctx, config, config.Previous)
+}
+
+func showLocationAreas(ctx context.Context, config *Config, pageURL string) (any, error) {
+	locations, err := config.Client.ListLocationAreas(ctx, pageURL)
+	if err != nil {
+		return nil, err
+	}
+
+	config.LastAreas = config.LastAreas[:0]
+	for i := 0; i < len(locations.Results); i++ {
+		config.LastAreas = append(config.LastAreas, locations.Results[i].Name)
+	}
+
+	names := newNamer(ctx, config)
+	for _, area := range config.LastAreas {
+		if err := names.area(area); err != nil {
+			return nil, err
+		}
+	}
+
+	config.Next = locations.Next
+	config.Previous = locations.Previous
+
+	return areaPage{
+		Areas:       append([]string(nil), config.LastAreas...),
+		HasNext:     locations.Next != "",
+		HasPrevious: locations.Previous != "",
+		Labels:      names.labels,
+	}, nil
+}
+
+func commandExplore(ctx context.Context, config *Config) (any, error) {
+	if err := notInBattle(config); err != nil {
+		return nil, err
+	}
+	name := strings.ToLower(config.Args[0])
+	locationArea, err := config.Client.GetLocationArea(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	version, method, err := chooseVersionAndMethod(config, locationArea)
+	if err != nil {
+		return nil, err
+	}
+
+	names := newNamer(ctx, config)
+	result := exploreResult{
+		Area:        name,
+		Version:     version,
+		Method:      method,
+		Pokemon:     []string{},
+		Encounters:  encounterTable(locationArea, version, config.Flags["method"]),
+		MethodRates: []methodRate{},
+		lang:        names.lang,
+	}
+	for _, slot := range result.Encounters {
+		if !slices.Contains(result.Pokemon, slot.Pokemon) {
+			result.Pokemon = append(result.Pokemon, slot.Pokemon)
+		}
+	}
+	if !names.english() {
+		names.add("location-area", name, locationArea.Names)
+		for _, pokemon := range result.Pokemon {
+			if err := names.pokemon(pokemon); err != nil {
+				return nil, err
+			}
+		}
+		result.Labels = names.labels
+	}
+
+	config.Pokedex.Session = pokedex.Session{Area: name, Version: version, Method: method}
+	for _, rate := range encounter.MethodRates(locationArea, version) {
+		if config.Flags["method"] == "" || rate.Method == method {
+			result.MethodRates = append(result.MethodRates, methodRate{rate.Method, rate.Rate})
+		}
+	}
+	result.Found = findItems(config, name)
+	if err := config.Pokedex.Save(config.PokedexPath); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func commandEncounter(ctx context.Context, config *Config) (any, error) {
+	if err := notInBattle(config); err != nil {
+		return nil, err
+	}
+	session := &config.Pokedex.Session
+	if session.Area == "" {
+		return nil, errors.New("you are nowhere yet, explore an area first")
+	}
+	locationArea, err := config.Client.GetLocationArea(ctx, session.Area)
+	if err != nil {
+		return nil, err
+	}
+
+	wild, ok := encounter.Roll(config.Rand, encounter.Slots(locationArea, session.Version, session.Method))
+	if !ok {
+		return nil, errors.New("no wild pokemon appear in " + session.Area + " with " + session.Method + " in " + session.Version)
+	}
+	session.Encounter = &pokedex.Encounter{Pokemon: wild.Pokemon, Level: wild.Level, Method: wild.Method}
+	if err := config.Pokedex.Save(config.PokedexPath); err != nil {
+		return nil, err
+	}
+	return encounterResult{
+		Area:    session.Area,
+		Version: session.Version,
+		Method:  wild.Method,
+		Pokemon: wild.Pokemon,
+		Level:   wild.Level,
+		lang:    language(config),
+	}, nil
+}
+
+func commandCatch(ctx context.Context, config *Config) (any, error) {
+	fight := config.Pokedex.Session.Battle
+	if fight != nil && fight.Kind == battle.Trainer {
+		return nil, errors.New("you can't catch another trainer's pokemon")
+	}
+	wild := config.Pokedex.Session.Encounter
+	if wild == nil {
+		return nil, errors.New("there is no wild pokemon in front of you, use encounter to look for one")
+	}
+	name := wild.Pokemon
+	if len(config.Args) > 0 && strings.ToLower(config.Args[0]) != name {
+		return nil, errors.New("there is no wild " + strings.ToLower(config.Args[0]) + " here, only a wild " + name)
+	}
+	status, err := capture.ParseStatus(config.Flags["status"])
+	if err != nil {
+		return nil, &usageError{cmdRegistry["catch"], err.Error()}
+	}
+	hp := 100
+	if fight != nil {
+		foe := fight.Opponent.Fighter()
+		hp = max(foe.HP*100/foe.Stats.HP, 1)
+	}
+	if val, ok := config.Flags["hp"]; ok {
+		if hp, err = strconv.Atoi(val); err != nil || hp < 1 || hp > 100 {
+			return nil, &usageError{cmdRegistry["catch"], i18n.T(language(config), "--hp must be a percentage from 1 to 100")}
+		}
+	}
+
+	ball, err := pickBall(config)
+	if err != nil {
+		return nil, err
+	}
+
+	pokemon, err := config.Client.GetPokemon(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	species, err := config.Client.GetPokemonSpecies(ctx, pokemon.Species.Name)
+	if err != nil {
+		return nil, err
+	}
+	var eff battle.Effectiveness
+	if fight != nil {
+		if eff, err = typeEffectiveness(ctx, config); err != nil {
+			return nil, err
+		}
+	}
+
+	conditions := capture.Conditions{
+		Level:         wild.Level,
+		Throws:        wild.Throws,
+		AlreadyCaught: config.Pokedex.Pokemon[name].ID != 0,
+		Dark:          isDark(config.Pokedex.Session.Area, time.Now()),
+		Water:         isWaterMethod(wild.Method),
+	}
+	for _, t := range pokemon.Types {
+		conditions.Types = append(conditions.Types, t.Type.Name)
+	}
+	attempt := capture.Attempt{
+		CaptureRate: species.CaptureRate,
+		HP:          float64(hp) / 100,
+		Ball:        ball.Modifier(conditions),
+		Status:      status,
+	}
+
+	config.Pokedex.UseItem(string(ball))
+	wild.Throws++
+	result := catchResult{Pokemon: name, Level: wild.Level, Ball: string(ball), Chance: attempt.Chance(), lang: language(config)}
+	result.BallsLeft = config.Pokedex.Inventory[string(ball)]
+	result.Shakes, result.Caught = attempt.Throw(config.Rand)
+	if result.Caught {
+		caught := config.Pokedex.Add(pokedex.CaughtPokemon{
+			Name:       name,
+			Level:      wild.Level,
+			IVs:        stats.RollIVs(config.Rand),
+			Nature:     stats.RollNature(config.Rand).Name,
+			Gender:     stats.RollGender(config.Rand, species.GenderRate),
+			Shiny:      stats.RollShiny(config.Rand),
+			Ability:    stats.RollAbility(config.Rand, pokemon),
+			Friendship: species.BaseHappiness,
+			Ball:       string(ball),
+			CaughtAt:   time.Now().UTC(),
+			Location:   config.Pokedex.Session.Area,
+		}, pokemon)
+		result.ID = caught.ID
+		config.Pokedex.Session.Encounter = nil
+		config.Pokedex.Session.Battle = nil
+	} else if fight != nil {
+		// A wild Pokemon that breaks free gets its turn in battle.
+		result.Battle = fight.Turn(language(config), config.Rand, eff, battle.Action{Kind: battle.Pass})
+		finishBattle(config, fight)
+	}
+	if err := config.Pokedex.Save(config.PokedexPath); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func commandInspect(ctx context.Context, config *Config) (any, error) {
+	if id, err := parseID(config.Args[0]); err == nil {
+		return inspectCaught(ctx, config, id)
+	}
+	name := strings.ToLower(config.Args[0])
+	pokemon, ok := config.Pokedex.Pokemon[name]
+	if !ok {
+		return nil, errors.New("you have not caught " + name + " yet")
+	}
+
+	species, err := config.Client.GetPokemonSpecies(ctx, pokemon.Species.Name)
+	if err != nil {
+		return nil, err
+	}
+	names := newNamer(ctx, config)
+	lang := names.lang
+	text, version, err := flavorText(species, strings.ToLower(config.Flags["version"]), lang, config.Pokedex.Session.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	result := inspectResult{
+		Name:          name,
+		Height:        pokemon.Height,
+		Weight:        pokemon.Weight,
+		measures:      measurements(pokemon.Height, pokemon.Weight),
+		Genus:         genus(species, lang),
+		FlavorText:    text,
+		FlavorVersion: version,
+		Generation:    species.Generation.Name,
+		Color:         species.Color.Name,
+		EggGroups:     []string{},
+		GenderRatio:   genderRatio(species.GenderRate),
+		CaptureRate:   species.CaptureRate,
+		Stats:         []statValue{},
+		Types:         []string{},
+		Abilities:     []abilitySlot{},
+		lang:          lang,
+	}
+	if species.Habitat != nil {
+		result.Habitat = species.Habitat.Name
+	}
+	for _, group := range species.EggGroups {
+		result.EggGroups = append(result.EggGroups, group.Name)
+	}
+	for i := 0; i < len(pokemon.Stats); i++ {
+		result.Stats = append(result.Stats, statValue{pokemon.Stats[i].Stat.Name, pokemon.Stats[i].BaseStat})
+	}
+	for i := 0; i < len(pokemon.Types); i++ {
+		result.Types = append(result.Types, pokemon.Types[i].Type.Name)
+	}
+	for _, a := range pokemon.Abilities {
+		result.Abilities = append(result.Abilities, abilitySlot{a.Ability.Name, a.IsHidden, a.Slot})
+	}
+	slices.SortStableFunc(result.Abilities, func(a, b abilitySlot) int { return a.Slot - b.Slot })
+	if !names.english() {
+		names.add("pokemon", name, species.Names)
+		for _, stat := range result.Stats {
+			if err := names.stat(stat.Name); err != nil {
+				return nil, err
+			}
+		}
+		for _, typ := range result.Types {
+			if err := names.typ(typ); err != nil {
+				return nil, err
+			}
+		}
+		for _, a := range result.Abilities {
+			if err := names.ability(a.Name); err != nil {
+				return nil, err
+			}
+		}
+		result.Labels = names.labels
+	}
+	return result, nil
+}
+
+func commandPokedex(ctx context.Context, config *Config) (any, error) {
+	names := newNamer(ctx, config)
+	result := pokedexResult{Pokemon: append([]string{}, config.Pokedex.Names()...), lang: names.lang}
+	for _, name := range result.Pokemon {
+		if err := names.pokemon(name); err != nil {
+			return nil, err
+		}
+	}
+	result.Labels = names.labels
+	return result, nil
+}
+
+func commandCache(ctx context.Context, config *Config) (any, error) {
+	switch config.Args[0] {
+	case "stats":
+		cache := config.Client.CacheStats()
+		result := cacheStatsResult{
+			MemoryEntries: cache.MemoryEntries,
+			MemoryHits:    cache.MemoryHits,
+			DiskHits:      cache.DiskHits,
+			Fetches:       cache.Fetches,
+			lang:          language(config),
+		}
+		if cache.Disk != nil {
+			result.DiskEnabled = true
+			result.DiskEntries = cache.Disk.Entries
+			result.DiskBytes = cache.Disk.Bytes
+			result.DiskMaxBytes = cache.Disk.MaxBytes
+			result.DiskEvictions = cache.Disk.Evictions
+		}
+		return result, nil
+	case "clear":
+		if err := config.Client.ClearCache(); err != nil {
+			return nil, err
+		}
+		return messageResult{i18n.T(language(config), "Cache cleared")}, nil
+	default:
+		return nil, &usageError{cmdRegistry["cache"], i18n.Sprintf(language(config), "unknown cache action %s", config.Args[0])}
+	}
+}
+
+// prefetchTarget is one kind of resource prefetch caches.
+type prefetchTarget struct {
+	// name is the argument that prefetches only this target, and label
+	// what progress calls it.
+	name, label string
+	list        func(client *pokeapi.Client, ctx context.Context) ([]pokeapi.NamedResource, error)
+	fetch       func(client *pokeapi.Client, ctx context.Context, name string) error
+	// count is the result field counting the resources cached.
+	count func(r *prefetchResult) *int
+}
+
+// prefetchTargets are the resources the commands read, so that every
+// command works offline after a full prefetch.
+var prefetchTargets = []prefetchTarget{
+	{"areas", "location areas", (*pokeapi.Client).ListAllLocationAreas, func(client *pokeapi.Client, ctx context.Context, name string) error {
+		_, err := client.GetLocationArea(ctx, name)
+		return err
+	}, func(r *prefetchResult) *int { return &r.LocationAreas }},
+	{"pokemon", "pokemon", (*pokeapi.Client).ListAllPokemon, func(client *pokeapi.Client, ctx context.Context, name string) error {
+		_, err := client.GetPokemon(ctx, name)
+		return err
+	}, func(r *prefetchResult) *int { return &r.Pokemon }},
+	{"species", "species and evolution chains", (*pokeapi.Client).ListAllSpecies, func(client *pokeapi.Client, ctx context.Context, name string) error {
+		_, err := client.GetSpeciesEvolutionChain(ctx, name)
+		return err
+	}, func(r *prefetchResult) *int { return &r.Species }},
+	{"moves", "moves", (*pokeapi.Client).ListAllMoves, func(client *pokeapi.Client, ctx context.Context, name string) error {
+		_, err := client.GetMove(ctx, name)
+		return err
+	}, func(r *prefetchResult) *int { return &r.Moves }},
+	{"abilities", "abilities", (*pokeapi.Client).ListAllAbilities, func(client *pokeapi.Client, ctx context.Context, name string) error {
+		_, err := client.GetAbility(ctx, name)
+		return err
+	}, func(r *prefetchResult) *int { return &r.Abilities }},
+	{"types", "types", (*pokeapi.Client).ListAllTypes, func(client *pokeapi.Client, ctx context.Context, name string) error {
+		_, err := client.GetType(ctx, name)
+		return err
+	}, func(r *prefetchResult) *int { return &r.Types }},
+	{"stats", "stats", (*pokeapi.Client).ListAllStats, func(client *pokeapi.Client, ctx context.Context, name string) error {
+		_, err := client.GetStat(ctx, name)
+		return err
+	}, func(r *prefetchResult) *int { return &r.Stats }},
+}
+
+func prefetchTargetNames() []string {
+	names := make([]string, len(prefetchTargets))
+	for i, target := range prefetchTargets {
+		names[i] = target.name
+	}
+	return names
+}
+
+func commandPrefetch(ctx context.Context, config *Config) (any, error) {
+	if config.Client.Offline() {
+		return nil, errors.New("prefetch needs network access, restart without --offline")
+	}
+	targets := prefetchTargets
+	if len(config.Args) > 0 {
+		i := slices.Index(prefetchTargetNames(), config.Args[0])
+		if i < 0 {
+			return nil, &usageError{cmdRegistry["prefetch"], i18n.Sprintf(language(config), "unknown target %s", config.Args[0])}
+		}
+		targets = targets[i : i+1]
+	}
+
+	before := config.Client.CacheStats()
+	lang := language(config)
+	result := prefetchResult{targets: targets, lang: lang}
+	for _, target := range targets {
+		resources, err := target.list(config.Client, ctx)
+		if err != nil {
+			return nil, err
+		}
+		names := make([]string, len(resources))
+		for i, res := range resources {
+			names[i] = res.Name
+		}
+		failed, err := prefetch(ctx, lang, target.label, names, func(name string) error {
+			return target.fetch(config.Client, ctx, name)
+		})
+		if err != nil {
+			return nil, err
+		}
+		*target.count(&result) = len(names) - failed
+		result.Failed += failed
+	}
+
+	if after := config.Client.CacheStats(); after.Disk != nil && before.Disk != nil {
+		if evicted := after.Disk.Evictions - before.Disk.Evictions; evicted > 0 {
+			fmt.Fprintln(os.Stderr, i18n.Sprintf(lang, "Warning: the disk cache is full and dropped %d responses, raise --cache-size (now %d MiB) to keep everything offline",
+				evicted, after.Disk.MaxBytes>>20))
+		}
+	}
+	return result, nil
+}
+
+// fetchWorkers bounds how many requests bulk fetches have in flight. The
+// client's rate limit still applies.
+const fetchWorkers = 8
+
+// fetchAll calls fetch for every name, fetchWorkers at a time, and done
+// after each call. It returns the errors by name and stops starting new
+// calls once ctx is done.
+func fetchAll(ctx context.Context, names []string, fetch func(name string) error, done func()) map[string]error {
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		errs = map[string]error{}
+		sem  = make(chan struct{}, fetchWorkers)
+	)
+	for _, name := range names {
+		if ctx.Err() != nil {
+			break
+		}
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() { <-sem; wg.Done() }()
+			err := fetch(name)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs[name] = err
+			}
+			if done != nil {
+				done()
+			}
+		}()
+	}
+	wg.Wait()
+	return errs
+}
+
+// prefetch fetches every resource, reporting progress in lang on stderr so
+// it stays out of structured output, and returns how many failed.
+func prefetch(ctx context.Context, lang, label string, names []string, fetch func(name string) error) (int, error) {
+	fetched := 0
+	errs := fetchAll(ctx, names, fetch, func() {
+		fetched++
+		fmt.Fprintf(os.Stderr, "\r%s", i18n.Sprintf(lang, "Prefetching %s %d/%d", i18n.T(lang, label), fetched, len(names)))
+	})
+	fmt.Fprintln(os.Stderr)
+	return len(errs), ctx.Err()
+}
+
+func commandSave(ctx context.Context, config *Config) (any, error) {
+	if err := config.Pokedex.Save(config.PokedexPath); err != nil {
+		return nil, err
+	}
+	return messageResult{i18n.Sprintf(language(config), "Pokedex saved to %s", config.PokedexPath)}, nil
+}
+
+func commandLoad(ctx context.Context, config *Config) (any, error) {
+	loaded, err := pokedex.Open(config.Args[0])
+	if err != nil {
+		return nil, err
+	}
+	// From now on save writes to the loaded file, not the one loaded at
+	// startup.
+	config.Pokedex = loaded
+	config.PokedexPath = config.Args[0]
+	return messageResult{i18n.Sprintf(language(config), "Loaded %d pokemon from %s, which is saved from now on", len(loaded.Caught), config.Args[0])}, nil
+}
+
+func commandReset(ctx context.Context, config *Config) (any, error) {
+	config.Pokedex.Reset()
+	return messageResult{i18n.T(language(config), "Pokedex cleared")}, nil
+}
+
+func init() {
+	cmdRegistry = map[string]cliCommand{
+		"pokedex": {
+			name:        "pokedex",
+			description: "List all names of the pokemon the user has caught",
+			callback:    commandPokedex,
+		},
+		"inspect": {
+			name:        "inspect",
+			args:        []argDef{{name: "pokemon|id"}},
+			description: "Inspects a pokemon and displays its species, Pokedex entry, size, stats, type(s) and abilities, or the details of one you caught",
+			flags: []flagDef{
+				{name: "version", value: "game", usage: "game whose Pokedex entry to show, e.g. firered (default the explored one, or the newest)"},
+			},
+			callback: commandInspect,
+		},
+		"catch": {
+			name:        "catch",
+			args:        []argDef{{name: "pokemon", optional: true}},
+			description: "Throws a ball at the wild pokemon you encountered",
+			flags: []flagDef{
+				{name: "hp", value: "percent", usage: "HP the pokemon has left, from 1 to 100 (default 100, or what is left in battle)"},
+				{name: "status", value: "condition", usage: "none, sleep, freeze, paralysis, burn or poison"},
+				{name: "ball", value: "ball", usage: "ball to throw, e.g. great or ultra (default poke)"},
+			},
+			callback: commandCatch,
+		},
+		"list": {
+			name:        "list",
+			args:        []argDef{{name: "pokemon", optional: true}},
+			description: "Lists the pokemon you caught, with their IDs",
+			callback:    commandList,
+		},
+		"rename": {
+			name:        "rename",
+			args:        []argDef{{name: "id"}, {name: "nickname"}},
+			description: "Gives a caught pokemon a nickname, or removes it with \"\"",
+			callback:    commandRename,
+		},
+		"release": {
+			name:        "release",
+			args:        []argDef{{name: "id"}},
+			description: "Releases one caught pokemon",
+			callback:    commandRelease,
+		},
+		"inventory": {
+			name:        "inventory",
+			description: "Lists the balls and evolution stones in your bag",
+			callback:    commandInventory,
+		},
+		"explore": {
+			name:        "explore",
+			args:        []argDef{{name: "area"}},
+			description: "Goes to an area and displays the pokemon found there",
+			flags: []flagDef{
+				{name: "version", value: "game", usage: "game version whose encounters to use, e.g. firered"},
+				{name: "method", value: "method", usage: "only show and encounter pokemon found this way, e.g. walk, surf or old-rod"},
+			},
+			callback: commandExplore,
+		},
+		"battle": {
+			name:        "battle",
+			description: "Battles the wild pokemon you encountered, or a trainer of the area, with your team",
+			flags: []flagDef{
+				{name: "team", value: "ids", usage: "IDs of the pokemon to battle with, e.g. 3,1,7 (default your strongest six)"},
+				{name: "trainer", usage: "battle a trainer with pokemon of the area instead"},
+				{name: "auto", usage: "let your pokemon fight on their own until the battle is over"},
+			},
+			callback: commandBattle,
+		},
+		"fight": {
+			name:        "fight",
+			args:        []argDef{{name: "move"}},
+			description: "Uses a move, by name or number, in the current battle",
+			callback:    commandFight,
+		},
+		"switch": {
+			name:        "switch",
+			args:        []argDef{{name: "id"}},
+			description: "Sends out another pokemon of your team in the current battle",
+			callback:    commandSwitch,
+		},
+		"flee": {
+			name:        "flee",
+			description: "Runs away from a wild pokemon battle",
+			callback:    commandFlee,
+		},
+		"types": {
+			name:        "types",
+			args:        []argDef{{name: "type[/type]"}},
+			description: "Shows the weaknesses, resistances and immunities of a type or two, e.g. fire/flying",
+			callback:    commandTypes,
+		},
+		"matchup": {
+			name:        "matchup",
+			args:        []argDef{{name: "attacker"}, {name: "defender"}},
+			description: "Shows the type multipliers between two caught pokemon, by ID or name",
+			callback:    commandMatchup,
+		},
+		"evolution": {
+			name:        "evolution",
+			args:        []argDef{{name: "pokemon|id"}},
+			description: "Shows the evolution chain of a pokemon with what each evolution takes",
+			callback:    commandEvolution,
+		},
+		"evolve": {
+			name:        "evolve",
+			args:        []argDef{{name: "id"}},
+			description: "Evolves a caught pokemon that meets the conditions, using up the item it takes",
+			flags: []flagDef{
+				{name: "into", value: "pokemon", usage: "pokemon to evolve into, for species with several evolutions"},
+			},
+			callback: commandEvolve,
+		},
+		"moves": {
+			name:        "moves",
+			args:        []argDef{{name: "pokemon|id"}},
+			description: "Lists the moves a pokemon learns in a version group, by level",
+			flags: []flagDef{
+				{name: "version-group", value: "group", usage: "version group to list, by default the newest one"},
+				{name: "method", value: "method", usage: "only moves learned by level-up, machine, egg or tutor"},
+			},
+			callback: commandMoves,
+		},
+		"move": {
+			name:        "move",
+			args:        []argDef{{name: "name"}},
+			description: "Shows the power, accuracy, PP, type and effect of a move",
+			callback:    commandMove,
+		},
+		"ability": {
+			name:        "ability",
+			args:        []argDef{{name: "name"}},
+			description: "Shows what an ability does and the pokemon that can have it",
+			callback:    commandAbility,
+		},
+		"encounter": {
+			name:        "encounter",
+			description: "Looks for a wild pokemon in the explored area",
+			callback:    commandEncounter,
+		},
+		"map": {
+			name:        "map",
+			description: "Displays all areas",
+			callback:    commandMap,
+		},
+		"mapb": {
+			name:        "mapb",
+			description: "Displays all areas",
+			callback:    commandMapb,
+		},
+		"save": {
+			name:        "save",
+			description: "Saves the caught pokemon to disk",
+			callback:    commandSave,
+		},
+		"load": {
+			name:        "load",
+			args:        []argDef{{name: "file"}},
+			description: "Replaces the caught pokemon with those saved in a file and saves to that file from now on, leaving them as they are if the file cannot be read",
+			callback:    commandLoad,
+		},
+		"reset": {
+			name:        "reset",
+			description: "Releases every caught pokemon",
+			callback:    commandReset,
+		},
+		"cache": {
+			name:        "cache",
+			args:        []argDef{{name: "stats|clear"}},
+			description: "Shows cache statistics or empties the cache",
+			callback:    commandCache,
+		},
+		"prefetch": {
+			name:        "prefetch",
+			args:        []argDef{{name: "areas|pokemon|species|moves|abilities|types|stats", optional: true}},
+			description: "Caches every resource the commands use, or one kind of them, for offline use",
+			callback:    commandPrefetch,
+		},
+		"source": {
+			name:        "source",
+			description: "Runs the commands in a script file",
+			args:        []argDef{{name: "file"}},
+			flags:       scriptFlags,
+			callback:    commandSource,
+		},
+		"run": {
+			name:        "run",
+			description: "Runs the commands in a script file, like source",
+			args:        []argDef{{name: "file"}},
+			flags:       scriptFlags,
+			callback:    commandSource,
+		},
+		"set": {
+			name:        "set",
+			description: "Sets a variable (set name value), lists them, or toggles script options -e/+e and -x/+x",
+			args:        []argDef{{name: "name", optional: true}, {name: "value", optional: true}},
+			callback:    commandSet,
+		},
+		"exit": {
+			name:        "exit",
+			description: "Exit the Pokedex",
+			callback:    commandExit,
+		},
+		"help": {
+			name:        "help",
+			args:        []argDef{{name: "command", optional: true}},
+			description: "Displays a help message, or the usage of one command",
+			callback:    commandHelp,
+		},
+	}
+}
+
+// runLine parses and runs one command line. Blank lines are ignored.
+func runLine(ctx context.Context, conf *Config, line string) error {
+	line, err := expandVars(line, conf.Vars)
+	if err != nil {
+		return err
+	}
+	words, err := cleanInput(line)
+	if err != nil || len(words) == 0 {
+		return err
+	}
+	return runCommand(ctx, conf, words)
+}
+
+// runCommand runs the command named by words[0] with the remaining words
+// as its arguments. The REPL and one-shot mode both dispatch through it.
+func runCommand(ctx context.Context, conf *Config, words []string) error {
+	cmd, ok := cmdRegistry[strings.ToLower(words[0])]
+	if !ok {
+		return fmt.Errorf("%w %q, type help for a list of commands", errUnknownCommand, words[0])
+	}
+	args, flags, err := parseArgs(sessionLanguage(conf), cmd, words[1:])
+	if err != nil {
+		return err
+	}
+
+	format := conf.Output
+	if name, ok := flags["output"]; ok {
+		if format, err = render.ParseFormat(name); err != nil {
+			return &usageError{cmd, err.Error()}
+		}
+	}
+
+	conf.Args = args
+	conf.Flags = flags
+	result, err := cmd.callback(ctx, conf)
+	if err != nil || result == nil {
+		return err
+	}
+	return render.Write(os.Stdout, format, result)
+}
+
+// repl reads commands until EOF. Ctrl-C cancels the running command; at the
+// prompt it closes the Pokedex like Ctrl-D does.
+func repl(conf *Config) {
+	// mu guards cancelCommand, set while a command runs, and whether
+	// Ctrl-C cancelled it and was then pressed again to quit.
+	var mu sync.Mutex
+	var cancelCommand context.CancelFunc
+	var cancelled, quit bool
+
+	interrupts := make(chan os.Signal, 1)
+	signal.Notify(interrupts, os.Interrupt)
+	defer signal.Stop(interrupts)
+	go func() {
+		for range interrupts {
+			mu.Lock()
+			fmt.Println()
+			if cancelCommand != nil {
+				// A second Ctrl-C quits, but only once the command has
+				// returned: the main loop saves the Pokedex then.
+				quit = cancelled
+				cancelled = true
+				cancelCommand()
+				mu.Unlock()
+				continue
+			}
+			// Nothing is running and, holding mu, nothing can start: the
+			// Pokedex can be saved from here.
+			if err := closePokedex(conf); err != nil {
+				fmt.Fprintln(os.Stderr, describeError(conf, err))
+				os.Exit(1)
+			}
+			os.Exit(0)
+		}
+	}()
+
+	editor := lineedit.New(os.Stdin, os.Stdout)
+	editor.Complete = completeLine(conf)
+	if dir, err := xdg.DataHome(); err == nil {
+		history, err := lineedit.LoadHistory(filepath.Join(dir, "history"), 1000)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Warning: could not read the command history:", err)
+		}
+		editor.History = history
+	}
+
+	historyFailed := false
+	for {
+		line, err := editor.ReadLine(i18n.T(sessionLanguage(conf), "Pokedex > "))
+		if errors.Is(err, io.EOF) || errors.Is(err, lineedit.ErrInterrupted) {
+			break
+		}
+		if err != nil {
+			fmt.Printf("Error during scanning: %v\n", err)
+			break
+		}
+		if err := editor.History.Add(line); err != nil && !historyFailed {
+			fmt.Fprintln(os.Stderr, "Warning: could not save the command history:", err)
+			historyFailed = true
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		mu.Lock()
+		cancelCommand = cancel
+		mu.Unlock()
+
+		err = runLine(ctx, conf, line)
+		if err != nil && !errors.Is(err, errExit) {
+			fmt.Fprintln(os.Stderr, describeError(conf, err))
+		}
+
+		mu.Lock()
+		cancelCommand, cancelled = nil, false
+		stop := quit || errors.Is(err, errExit)
+		mu.Unlock()
+		cancel()
+		if stop {
+			break
+		}
+	}
+
+	fmt.Println()
+	if err := closePokedex(conf); err != nil {
+		fmt.Fprintln(os.Stderr, describeError(conf, err))
+	}
+}
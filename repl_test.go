@@ -1,36 +1,819 @@
-package main
-
-import (
-	"fmt"
-	"testing"
-)
-
-func TestCleanInput(t *testing.T) {
-	// ...
-	cases := []struct {
-		input    string
-		expected []string
-	}{
-		{
-			input:    "  hello  world  ",
-			expected: []string{"hello", "world"},
-		},
-		// add more cases here
-	}
-
-	for _, c := range cases {
-		actual := cleanInput(c.input)
-		// Check the length of the actual slice against the expected slice
-		// if they don't match, use t.Errorf to print an error message
-		// and fail the test
-		for i := range actual {
-			word := actual[i]
-			expectedWord := c.expected[i]
-
-			fmt.Println(word + " == " + expectedWord)
-			// Check each word in the slice
-			// if they don't match, use t.Errorf to print an error message
-			// and fail the test
-		}
-	}
-}
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/anegri01f01/pokegocli/internal/diskcache"
+	"github.com/anegri01f01/pokegocli/internal/fixtures"
+	"github.com/anegri01f01/pokegocli/internal/pokeapi"
+	"github.com/anegri01f01/pokegocli/internal/pokedex"
+	"github.com/anegri01f01/pokegocli/internal/render"
+	"github.com/anegri01f01/pokegocli/internal/stats"
+)
+
+func TestCleanInput(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected []string
+	}{
+		{
+			input:    "  hello  world  ",
+			expected: []string{"hello", "world"},
+		},
+		{
+			input:    "rename 3 \"Sir Pidge\"",
+			expected: []string{"rename", "3", "Sir Pidge"},
+		},
+		{
+			input:    "load '/tmp/my dex.json' --force",
+			expected: []string{"load", "/tmp/my dex.json", "--force"},
+		},
+		{
+			input:    `say it\'s "a \"quote\""`,
+			expected: []string{"say", "it's", `a "quote"`},
+		},
+		{
+			input:    "catch ''",
+			expected: []string{"catch", ""},
+		},
+		{
+			input:    "   ",
+			expected: nil,
+		},
+	}
+
+	for _, c := range cases {
+		actual, err := cleanInput(c.input)
+		if err != nil {
+			t.Errorf("cleanInput(%q): %v", c.input, err)
+			continue
+		}
+		if len(actual) != len(c.expected) {
+			t.Errorf("cleanInput(%q) = %q, want %q", c.input, actual, c.expected)
+			continue
+		}
+		for i := range actual {
+			if actual[i] != c.expected[i] {
+				t.Errorf("cleanInput(%q)[%d] = %q, want %q", c.input, i, actual[i], c.expected[i])
+			}
+		}
+	}
+
+	if _, err := cleanInput(`explore "canalave`); err == nil {
+		t.Error("expected an error for an unterminated quote")
+	}
+}
+
+func newFixtureConfig(t *testing.T) *Config {
+	t.Helper()
+	srv := fixtures.NewServer()
+	t.Cleanup(srv.Close)
+	return &Config{
+		Client:      pokeapi.NewClient(pokeapi.WithBaseURL(srv.BaseURL())),
+		Pokedex:     pokedex.New(),
+		PokedexPath: filepath.Join(t.TempDir(), pokedex.FileName),
+		Rand:        rand.New(rand.NewSource(1)),
+	}
+}
+
+// meet puts a wild Pokemon in front of the trainer.
+func meet(conf *Config, pokemon string, level int) {
+	conf.Pokedex.Session.Encounter = &pokedex.Encounter{Pokemon: pokemon, Level: level, Method: "walk"}
+}
+
+// decode builds a PokeAPI value from the JSON the API would send.
+func decode[T any](t *testing.T, data string) T {
+	t.Helper()
+	var v T
+	if err := json.Unmarshal([]byte(data), &v); err != nil {
+		t.Fatalf("bad %T: %v", v, err)
+	}
+	return v
+}
+
+func captureOutput(t *testing.T, fn func() error) string {
+	t.Helper()
+	return captureFile(t, &os.Stdout, fn)
+}
+
+func captureStderr(t *testing.T, fn func() error) string {
+	t.Helper()
+	return captureFile(t, &os.Stderr, fn)
+}
+
+// captureFile runs fn with *file redirected and returns what it wrote there.
+func captureFile(t *testing.T, file **os.File, fn func() error) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	saved := *file
+	*file = w
+	defer func() { *file = saved }()
+
+	done := make(chan string)
+	go func() {
+		out, _ := io.ReadAll(r)
+		done <- string(out)
+	}()
+
+	if err := fn(); err != nil {
+		t.Errorf("command failed: %v", err)
+	}
+	w.Close()
+	return <-done
+}
+
+func TestCommandMapAgainstFixtures(t *testing.T) {
+	conf := newFixtureConfig(t)
+
+	out := captureOutput(t, func() error { return runLine(context.Background(), conf, "map") })
+	for _, area := range []string{"canalave-city-area", "viridian-forest-area", "kanto-route-1-area"} {
+		if !strings.Contains(out, area) {
+			t.Errorf("map output missing %q:\n%s", area, out)
+		}
+	}
+
+	out = captureOutput(t, func() error { return runLine(context.Background(), conf, "mapb") })
+	if !strings.Contains(out, "first page") {
+		t.Errorf("expected first page notice, got:\n%s", out)
+	}
+}
+
+func TestCommandExploreAgainstFixtures(t *testing.T) {
+	conf := newFixtureConfig(t)
+	out := captureOutput(t, func() error { return runLine(context.Background(), conf, "explore kanto-route-1-area") })
+	if !strings.Contains(out, " - pidgey") || !strings.Contains(out, " - rattata") {
+		t.Errorf("unexpected explore output:\n%s", out)
+	}
+}
+
+func TestCommandCatchAgainstFixtures(t *testing.T) {
+	conf := newFixtureConfig(t)
+	meet(conf, "pikachu", 5)
+	out := captureOutput(t, func() error { return runLine(context.Background(), conf, "catch pikachu") })
+	if !strings.Contains(out, "pikachu has a 24.8% chance to be caught\nThrowing a Poke Ball at pikachu...") {
+		t.Errorf("unexpected catch output:\n%s", out)
+	}
+}
+
+func TestCatchIsDeterministicWithSeed(t *testing.T) {
+	throw := func(seed int64) string {
+		conf := newFixtureConfig(t)
+		conf.Rand = rand.New(rand.NewSource(seed))
+		var out strings.Builder
+		out.WriteString(captureOutput(t, func() error {
+			return runLine(context.Background(), conf, "explore kanto-route-1-area --version firered -o json")
+		}))
+		for i := 0; i < 5; i++ {
+			out.WriteString(captureOutput(t, func() error {
+				if err := runLine(context.Background(), conf, "encounter -o json"); err != nil {
+					return err
+				}
+				return runLine(context.Background(), conf, "catch --hp 20 --status sleep -o json")
+			}))
+		}
+		return out.String()
+	}
+	if a, b := throw(4), throw(4); a != b {
+		t.Errorf("same seed gave different catches:\n%s\n%s", a, b)
+	}
+
+	conf := newFixtureConfig(t)
+	meet(conf, "pidgey", 3)
+	if err := runLine(context.Background(), conf, "catch pidgey --hp 0"); err == nil {
+		t.Error("expected an error for --hp 0")
+	}
+	if err := runLine(context.Background(), conf, "catch pidgey --status confused"); err == nil {
+		t.Error("expected an error for an unknown status")
+	}
+}
+
+func TestExploreShowsEncounterTable(t *testing.T) {
+	conf := newFixtureConfig(t)
+	out := captureOutput(t, func() error {
+		return runLine(context.Background(), conf, "explore hoenn-route-104-area --version emerald --method good-rod -o json")
+	})
+
+	var result exploreResult
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("explore -o json is not JSON: %v\n%s", err, out)
+	}
+	want := []encounterSlot{
+		{Pokemon: "magikarp", Method: "good-rod", Chance: 60, MinLevel: 10, MaxLevel: 30},
+		{Pokemon: "wingull", Method: "good-rod", Chance: 40, MinLevel: 10, MaxLevel: 30},
+	}
+	if !reflect.DeepEqual(result.Encounters, want) {
+		t.Errorf("encounters = %+v, want %+v", result.Encounters, want)
+	}
+	if len(result.MethodRates) != 1 || result.MethodRates[0] != (methodRate{"good-rod", 60}) {
+		t.Errorf("method rates = %+v", result.MethodRates)
+	}
+
+	out = captureOutput(t, func() error {
+		return runLine(context.Background(), conf, "explore kanto-route-1-area --version firered")
+	})
+	if !strings.Contains(out, " - pidgey   walk  55%  Lv. 2-7\n") {
+		t.Errorf("unexpected explore table:\n%s", out)
+	}
+}
+
+func TestCatchNeedsAnEncounter(t *testing.T) {
+	conf := newFixtureConfig(t)
+	ctx := context.Background()
+
+	if err := runLine(ctx, conf, "catch"); err == nil {
+		t.Error("expected catch to fail without an encounter")
+	}
+	if err := runLine(ctx, conf, "encounter"); err == nil {
+		t.Error("expected encounter to fail before exploring")
+	}
+	if err := runLine(ctx, conf, "explore canalave-city-area --version red"); err == nil {
+		t.Error("expected an error for a version without encounters")
+	}
+
+	captureOutput(t, func() error { return runLine(ctx, conf, "explore canalave-city-area --method old-rod") })
+	session := conf.Pokedex.Session
+	if session.Area != "canalave-city-area" || session.Version != "diamond" || session.Method != "old-rod" {
+		t.Errorf("unexpected session %+v", session)
+	}
+	out := captureOutput(t, func() error { return runLine(ctx, conf, "encounter") })
+	wild := conf.Pokedex.Session.Encounter
+	if wild == nil || wild.Pokemon != "magikarp" || wild.Level < 3 || wild.Level > 15 {
+		t.Fatalf("unexpected encounter %+v", wild)
+	}
+	if !strings.HasPrefix(out, "A wild magikarp (Lv. ") {
+		t.Errorf("unexpected encounter output:\n%s", out)
+	}
+	if err := runLine(ctx, conf, "catch mewtwo"); err == nil {
+		t.Error("expected catch to refuse a pokemon that is not here")
+	}
+}
+
+func TestCaughtPokemonAreIndividuals(t *testing.T) {
+	conf := newFixtureConfig(t)
+	ctx := context.Background()
+	conf.Pokedex.AddItem("master-ball", 2)
+	for _, level := range []int{3, 4} {
+		meet(conf, "pidgey", level)
+		captureOutput(t, func() error { return runLine(ctx, conf, "catch --ball master") })
+	}
+
+	var list listResult
+	out := captureOutput(t, func() error { return runLine(ctx, conf, "list pidgey -o json") })
+	if err := json.Unmarshal([]byte(out), &list); err != nil {
+		t.Fatalf("list -o json is not JSON: %v\n%s", err, out)
+	}
+	if len(list.Pokemon) != 2 || list.Pokemon[0].ID != 1 || list.Pokemon[1].ID != 2 || list.Pokemon[1].Level != 4 {
+		t.Fatalf("unexpected list %+v", list.Pokemon)
+	}
+
+	captureOutput(t, func() error { return runLine(ctx, conf, `rename 2 "Sir Pidge"`) })
+	out = captureOutput(t, func() error { return runLine(ctx, conf, "inspect #2") })
+	if !strings.HasPrefix(out, "#2 Sir Pidge (pidgey) Lv. 4 ") || !strings.Contains(out, "  -hp: ") {
+		t.Errorf("unexpected inspect output:\n%s", out)
+	}
+
+	var caught caughtResult
+	out = captureOutput(t, func() error { return runLine(ctx, conf, "inspect 2 -o json") })
+	if err := json.Unmarshal([]byte(out), &caught); err != nil {
+		t.Fatalf("inspect -o json is not JSON: %v\n%s", err, out)
+	}
+	// Pidgey's base HP is 40: (2*40+IV)*4/100 + 4 + 10.
+	if want := (80+caught.IVs.HP)*4/100 + 14; caught.Stats.HP != want || caught.Ball != "master-ball" {
+		t.Errorf("unexpected stats %+v, want hp %d", caught.Stats, want)
+	}
+
+	captureOutput(t, func() error { return runLine(ctx, conf, "release 1") })
+	if len(conf.Pokedex.Caught) != 1 || conf.Pokedex.Caught[0].ID != 2 {
+		t.Errorf("unexpected pokemon after release: %+v", conf.Pokedex.Caught)
+	}
+	if err := runLine(ctx, conf, "release 1"); err == nil {
+		t.Error("expected releasing a missing pokemon to fail")
+	}
+	if err := runLine(ctx, conf, "rename pidgey Bob"); err == nil {
+		t.Error("expected rename to need an ID")
+	}
+}
+
+func TestInspectRollsMissingGender(t *testing.T) {
+	conf := newFixtureConfig(t)
+	ctx := context.Background()
+	conf.Pokedex.AddItem("master-ball", 1)
+	meet(conf, "pidgey", 3)
+	captureOutput(t, func() error { return runLine(ctx, conf, "catch --ball master") })
+	conf.Pokedex.Caught[0].Gender = ""
+
+	captureOutput(t, func() error { return runLine(ctx, conf, "inspect 1") })
+	gender := conf.Pokedex.Caught[0].Gender
+	if gender != stats.Male && gender != stats.Female {
+		t.Fatalf("expected pidgey to get a gender, got %q", gender)
+	}
+	saved, err := pokedex.Load(conf.PokedexPath)
+	if err != nil || saved.Caught[0].Gender != gender {
+		t.Errorf("the rolled gender was not saved: %v", err)
+	}
+}
+
+func TestSaveWritesTheLoadedFile(t *testing.T) {
+	conf := newFixtureConfig(t)
+	ctx := context.Background()
+	captureOutput(t, func() error { return runLine(ctx, conf, "save") })
+	before, err := os.ReadFile(conf.PokedexPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	other := pokedex.New()
+	other.AddItem("master-ball", 3)
+	path := filepath.Join(t.TempDir(), "other.json")
+	if err := other.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	defaultPath := conf.PokedexPath
+	captureOutput(t, func() error { return runLine(ctx, conf, "load "+path) })
+	conf.Pokedex.AddItem("master-ball", 1)
+	out := captureOutput(t, func() error { return runLine(ctx, conf, "save") })
+	if !strings.Contains(out, path) {
+		t.Errorf("save does not name the loaded file: %q", out)
+	}
+
+	if after, _ := os.ReadFile(defaultPath); string(after) != string(before) {
+		t.Error("save after load overwrote the default pokedex")
+	}
+	saved, err := pokedex.Load(path)
+	if err != nil || saved.Inventory["master-ball"] != 4 {
+		t.Errorf("save after load did not write the loaded file: %v, %v", saved.Inventory, err)
+	}
+}
+
+func TestBallsAreSpentAndFound(t *testing.T) {
+	conf := newFixtureConfig(t)
+	ctx := context.Background()
+	meet(conf, "pidgey", 3)
+
+	if err := runLine(ctx, conf, "catch pidgey --ball ultra"); err == nil || !strings.Contains(err.Error(), "no Ultra Balls") {
+		t.Fatalf("expected an out of balls error, got %v", err)
+	}
+	conf.Pokedex.AddItem("ultra-ball", 1)
+	captureOutput(t, func() error { return runLine(ctx, conf, "catch pidgey --ball ultra") })
+	if n := conf.Pokedex.Inventory["ultra-ball"]; n != 0 {
+		t.Errorf("ultra ball not spent, %d left", n)
+	}
+
+	before := 0
+	for _, n := range conf.Pokedex.Inventory {
+		before += n
+	}
+	captureOutput(t, func() error { return runLine(ctx, conf, "explore kanto-route-1-area") })
+	after := 0
+	for _, n := range conf.Pokedex.Inventory {
+		after += n
+	}
+	if after < before+firstVisitFinds {
+		t.Errorf("first visit should find at least %d balls, inventory went from %d to %d", firstVisitFinds, before, after)
+	}
+	if !conf.Pokedex.Explored["kanto-route-1-area"] {
+		t.Error("area not marked as explored")
+	}
+}
+
+func TestCommandOutputFormats(t *testing.T) {
+	conf := newFixtureConfig(t)
+	pikachu, err := conf.Client.GetPokemon(context.Background(), "pikachu")
+	if err != nil {
+		t.Fatal(err)
+	}
+	conf.Pokedex.Pokemon["pikachu"] = pikachu
+
+	out := captureOutput(t, func() error { return runLine(context.Background(), conf, "inspect pikachu -o json") })
+	var result struct {
+		Name  string `json:"name"`
+		Stats []struct {
+			Name  string `json:"name"`
+			Value int    `json:"value"`
+		} `json:"stats"`
+		Types []string `json:"types"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("inspect -o json is not JSON: %v\n%s", err, out)
+	}
+	if result.Name != "pikachu" || len(result.Stats) == 0 || len(result.Types) != 1 || result.Types[0] != "electric" {
+		t.Errorf("unexpected inspect result %+v", result)
+	}
+
+	conf.Output = render.YAML
+	out = captureOutput(t, func() error { return runLine(context.Background(), conf, "explore kanto-route-1-area") })
+	if !strings.HasPrefix(out, "area: kanto-route-1-area\nversion: red\nmethod: walk\npokemon:\n  - ") {
+		t.Errorf("unexpected yaml output:\n%s", out)
+	}
+
+	out = captureOutput(t, func() error { return runLine(context.Background(), conf, "pokedex --output=table") })
+	if !strings.HasPrefix(out, "POKEMON\npikachu\n") {
+		t.Errorf("unexpected table output:\n%s", out)
+	}
+
+	if err := runLine(context.Background(), conf, "pokedex -o xml"); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
+
+func TestDescribeErrorSuggestsClosestName(t *testing.T) {
+	conf := newFixtureConfig(t)
+
+	_, err := conf.Client.GetPokemon(context.Background(), "pikachuu")
+	got := describeError(conf, err)
+	want := "no Pokemon named 'pikachuu' — did you mean 'pikachu'?"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	_, err = conf.Client.GetLocationArea(context.Background(), "atlantis")
+	if got := describeError(conf, err); got != "no location area named 'atlantis'" {
+		t.Errorf("unexpected message %q", got)
+	}
+}
+
+func TestBattle(t *testing.T) {
+	conf := newFixtureConfig(t)
+	ctx := context.Background()
+	if err := runLine(ctx, conf, "battle"); err == nil {
+		t.Error("expected a battle without pokemon to fail")
+	}
+	conf.Pokedex.AddItem("master-ball", 2)
+	meet(conf, "pikachu", 30)
+	captureOutput(t, func() error { return runLine(ctx, conf, "catch --ball master") })
+
+	meet(conf, "magikarp", 5)
+	out := captureOutput(t, func() error { return runLine(ctx, conf, "battle --auto") })
+	for _, want := range []string{"A wild magikarp (Lv. 5) wants to battle!", "Go! pikachu!", "It's super effective!", "The wild magikarp fainted!"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if conf.Pokedex.Session.Battle != nil || conf.Pokedex.Session.Encounter != nil {
+		t.Error("the battle and the wild pokemon should be gone after winning")
+	}
+
+	meet(conf, "tentacool", 5)
+	out = captureOutput(t, func() error { return runLine(ctx, conf, "battle") })
+	if !strings.Contains(out, "Moves: 1 growl, 2 tail-whip, 3 quick-attack, 4 thunderbolt") {
+		t.Errorf("unexpected battle output:\n%s", out)
+	}
+	if err := runLine(ctx, conf, "encounter"); err == nil {
+		t.Error("expected encounter to fail during a battle")
+	}
+	if err := runLine(ctx, conf, "fight surf"); err == nil {
+		t.Error("expected a move pikachu does not know to fail")
+	}
+	if err := runLine(ctx, conf, "switch 1"); err == nil {
+		t.Error("expected switching to the battling pokemon to fail")
+	}
+	if err := runLine(ctx, conf, "release 1"); err == nil || len(conf.Pokedex.Caught) != 1 {
+		t.Errorf("expected releasing the battling pokemon to fail, got %v", err)
+	}
+	captureOutput(t, func() error { return runLine(ctx, conf, "fight 1") })
+	captureOutput(t, func() error { return runLine(ctx, conf, "catch --ball master") })
+	if conf.Pokedex.Session.Battle != nil || len(conf.Pokedex.Caught) != 2 {
+		t.Error("catching the wild pokemon should end the battle")
+	}
+}
+
+func TestTrainerBattle(t *testing.T) {
+	conf := newFixtureConfig(t)
+	ctx := context.Background()
+	conf.Pokedex.AddItem("master-ball", 1)
+	meet(conf, "pikachu", 30)
+	captureOutput(t, func() error { return runLine(ctx, conf, "catch --ball master") })
+	captureOutput(t, func() error { return runLine(ctx, conf, "explore kanto-route-1-area --version firered") })
+
+	var result battleResult
+	out := captureOutput(t, func() error { return runLine(ctx, conf, "battle --trainer -o json") })
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("battle -o json is not JSON: %v\n%s", err, out)
+	}
+	if result.Kind != "trainer" || result.Trainer != "Youngster" || result.Player.ID != 1 {
+		t.Errorf("unexpected battle %+v", result)
+	}
+	if err := runLine(ctx, conf, "flee"); err == nil {
+		t.Error("expected fleeing a trainer battle to fail")
+	}
+	if err := runLine(ctx, conf, "catch"); err == nil {
+		t.Error("expected catching a trainer's pokemon to fail")
+	}
+
+	captureOutput(t, func() error { return runLine(ctx, conf, "battle --auto") })
+	if conf.Pokedex.Session.Battle != nil {
+		t.Error("battle --auto should finish the battle")
+	}
+}
+
+func TestTypesAndMatchup(t *testing.T) {
+	conf := newFixtureConfig(t)
+	ctx := context.Background()
+	out := captureOutput(t, func() error { return runLine(ctx, conf, "types water/flying") })
+	want := "water/flying\nWeak to: electric x4, rock x2\nResists: fighting x0.5, bug x0.5, steel x0.5, fire x0.5, water x0.5\nImmune to: ground\n"
+	if out != want {
+		t.Errorf("types output:\n%s\nwant:\n%s", out, want)
+	}
+	if err := runLine(ctx, conf, "types fire/wood"); err == nil {
+		t.Error("expected an unknown type to fail")
+	}
+
+	conf.Pokedex.AddItem("master-ball", 2)
+	for _, wild := range []string{"pikachu", "gyarados"} {
+		meet(conf, wild, 30)
+		captureOutput(t, func() error { return runLine(ctx, conf, "catch --ball master") })
+	}
+	var result matchupResult
+	out = captureOutput(t, func() error { return runLine(ctx, conf, "matchup 1 gyarados -o json") })
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("matchup -o json is not JSON: %v\n%s", err, out)
+	}
+	if len(result.Attack) != 1 || result.Attack[0].Multiplier != 4 || len(result.Defend) != 2 || result.Defend[1].Multiplier != 0.5 {
+		t.Errorf("unexpected multipliers %+v %+v", result.Attack, result.Defend)
+	}
+	if len(result.Moves) != 4 || result.Moves[3] != (moveMatchup{"thunderbolt", "electric", "special", 4}) {
+		t.Errorf("unexpected moves %+v", result.Moves)
+	}
+	if err := runLine(ctx, conf, "matchup pikachu magikarp"); err == nil {
+		t.Error("expected a matchup with an uncaught pokemon to fail")
+	}
+}
+
+func TestEvolutionAndEvolve(t *testing.T) {
+	conf := newFixtureConfig(t)
+	ctx := context.Background()
+	out := captureOutput(t, func() error { return runLine(ctx, conf, "evolution raichu") })
+	want := "pichu (baby)\n└─ pikachu: level up with friendship 220\n   └─ raichu: use thunder-stone <\n"
+	if out != want {
+		t.Errorf("evolution output:\n%s\nwant:\n%s", out, want)
+	}
+
+	conf.Pokedex.AddItem("master-ball", 3)
+	for _, wild := range []string{"pichu", "pikachu", "magikarp"} {
+		meet(conf, wild, 10)
+		captureOutput(t, func() error { return runLine(ctx, conf, "catch --ball master") })
+	}
+	for _, line := range []string{"evolve 1", "evolve 2", "evolve 3", "evolve 2 --into pichu"} {
+		if err := runLine(ctx, conf, line); err == nil {
+			t.Errorf("%s: expected the conditions to be unmet", line)
+		}
+	}
+
+	conf.Pokedex.Caught[0].Friendship = 220
+	conf.Pokedex.Caught[2].Level = 20
+	conf.Pokedex.AddItem("thunder-stone", 1)
+	out = captureOutput(t, func() error { return runLine(ctx, conf, "evolve 2") })
+	if out != "You used a Thunder Stone.\nCongratulations! Your pikachu evolved into raichu!\n" {
+		t.Errorf("unexpected evolve output %q", out)
+	}
+	for _, line := range []string{"evolve 1", "evolve 3"} {
+		captureOutput(t, func() error { return runLine(ctx, conf, line) })
+	}
+	var names []string
+	for _, c := range conf.Pokedex.Caught {
+		names = append(names, c.Name)
+	}
+	if strings.Join(names, ",") != "pikachu,raichu,gyarados" || conf.Pokedex.Inventory["thunder-stone"] != 0 {
+		t.Errorf("unexpected pokemon %v and inventory %v", names, conf.Pokedex.Inventory)
+	}
+	if _, ok := conf.Pokedex.Pokemon["gyarados"]; !ok {
+		t.Error("gyarados should be in the pokedex")
+	}
+}
+
+func TestInspectShowsSpecies(t *testing.T) {
+	conf := newFixtureConfig(t)
+	ctx := context.Background()
+	for _, name := range []string{"pikachu", "magikarp"} {
+		data, err := conf.Client.GetPokemon(ctx, name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		conf.Pokedex.Pokemon[name] = data
+	}
+
+	out := captureOutput(t, func() error { return runLine(ctx, conf, "inspect pikachu --version leafgreen") })
+	want := "Name: pikachu\nGenus: Mouse Pokémon\nHeight: 0.4 m (1'04\")\nWeight: 6.0 kg (13.2 lbs)\n" +
+		"Generation: generation-i\nHabitat: forest\nColor: yellow\nEgg groups: ground, fairy\n" +
+		"Gender: 50% male, 50% female\nCapture rate: 190\n" +
+		"Pokedex (leafgreen): It stores electricity in the electric sacs on its cheeks. When it releases pent-up energy in a burst, the power is equal to a lightning bolt.\n"
+	if !strings.HasPrefix(out, want) {
+		t.Errorf("inspect output:\n%s\nwant it to start with:\n%s", out, want)
+	}
+
+	var result inspectResult
+	out = captureOutput(t, func() error { return runLine(ctx, conf, "inspect magikarp -o json") })
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("inspect -o json is not JSON: %v\n%s", err, out)
+	}
+	if result.measures != (measures{0.9, 2, 11, 10, 22}) || result.FlavorVersion != "pearl" || result.CaptureRate != 255 || result.GenderRatio.Female != 50 {
+		t.Errorf("unexpected inspect result %+v", result)
+	}
+	if err := runLine(ctx, conf, "inspect pikachu --version red"); err == nil {
+		t.Error("expected a version without an entry to fail")
+	}
+}
+
+func TestLocalizedNames(t *testing.T) {
+	conf := newFixtureConfig(t)
+	ctx := context.Background()
+	if err := runLine(ctx, conf, "set lang ja"); err != nil {
+		t.Fatal(err)
+	}
+	out := captureOutput(t, func() error { return runLine(ctx, conf, "map") })
+	if !strings.HasPrefix(out, "ミオシティ (canalave-city-area)\n1ばんどうろ (kanto-route-1-area)\n") {
+		t.Errorf("unexpected map output:\n%s", out)
+	}
+
+	data, err := conf.Client.GetPokemon(ctx, "pikachu")
+	if err != nil {
+		t.Fatal(err)
+	}
+	conf.Pokedex.Pokemon["pikachu"] = data
+	out = captureOutput(t, func() error { return runLine(ctx, conf, "inspect pikachu") })
+	for _, want := range []string{"なまえ: ピカチュウ\n分類: ねずみポケモン\n", "性別: オス 50%、メス 50%\n", "能力:\n  -HP: 35\n  -こうげき: 55\n", "タイプ:\n  - でんき\n"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("inspect output lacks %q:\n%s", want, out)
+		}
+	}
+
+	// A command's --lang wins over the session's, and names and entries
+	// missing in a language fall back to English.
+	out = captureOutput(t, func() error { return runLine(ctx, conf, "pokedex --lang ko") })
+	if out != "  - Pikachu (pikachu)\n" {
+		t.Errorf("unexpected pokedex output %q", out)
+	}
+	out = captureOutput(t, func() error { return runLine(ctx, conf, "inspect pikachu --lang fr") })
+	if !strings.Contains(out, "Nom : Pikachu\nCatégorie : Mouse Pokémon\n") || !strings.Contains(out, "Pokédex (pearl) : It ") {
+		t.Errorf("unexpected inspect output:\n%s", out)
+	}
+
+	var page areaPage
+	out = captureOutput(t, func() error { return runLine(ctx, conf, "map -o json --lang de") })
+	if err := json.Unmarshal([]byte(out), &page); err != nil {
+		t.Fatalf("map -o json is not JSON: %v\n%s", err, out)
+	}
+	if page.Areas[0] != "canalave-city-area" || page.Labels["location-area/canalave-city-area"] != "Fleetburg" {
+		t.Errorf("unexpected map result %+v", page)
+	}
+}
+
+func TestTranslatedMessages(t *testing.T) {
+	conf := newFixtureConfig(t)
+	ctx := context.Background()
+	if err := runLine(ctx, conf, "set lang ja"); err != nil {
+		t.Fatal(err)
+	}
+	out := captureOutput(t, func() error { return runLine(ctx, conf, "list") })
+	if out != "まだ ポケモンを つかまえていません\n" {
+		t.Errorf("unexpected list output %q", out)
+	}
+
+	conf.Pokedex.AddItem("master-ball", 2)
+	meet(conf, "pikachu", 30)
+	out = captureOutput(t, func() error { return runLine(ctx, conf, "catch --ball master") })
+	for _, want := range []string{"pikachu に Master Ball を なげた...\n", "やったー! pikachu を つかまえたぞ! リストの #1 です\n", "のこりの Master Ball: 1\n"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("catch output lacks %q:\n%s", want, out)
+		}
+	}
+	out = captureOutput(t, func() error { return runLine(ctx, conf, "inventory") })
+	if !strings.Contains(out, "  - Master Ball x1: かならず つかまえられる\n") {
+		t.Errorf("unexpected inventory output %q", out)
+	}
+	_, err := conf.Client.GetLocationArea(ctx, "atlantis")
+	if got := describeError(conf, err); got != "'atlantis' という エリア は ありません" {
+		t.Errorf("unexpected message %q", got)
+	}
+	out = captureOutput(t, func() error { return runLine(ctx, conf, "types water/flying --lang de") })
+	if !strings.Contains(out, "Schwach gegen: electric x4, rock x2\n") || !strings.Contains(out, "Immun gegen: ground\n") {
+		t.Errorf("unexpected types output:\n%s", out)
+	}
+
+	out = captureOutput(t, func() error { return runLine(ctx, conf, "help") })
+	if !strings.HasPrefix(out, "ずかんへ ようこそ!\nつかいかた:\n\n") {
+		t.Errorf("unexpected help output:\n%s", out)
+	}
+	err = runLine(ctx, conf, "cache shrink")
+	if got := describeError(conf, err); !strings.HasPrefix(got, "shrink という キャッシュの そうさは ありません\nつかいかた: cache ") {
+		t.Errorf("unexpected usage error %q", got)
+	}
+
+	// Languages without translations get the English messages.
+	meet(conf, "magikarp", 5)
+	out = captureOutput(t, func() error { return runLine(ctx, conf, "catch --ball master --lang ko") })
+	if !strings.Contains(out, "Throwing a Master Ball at magikarp...\n") || !strings.Contains(out, "Master Balls left: 0\n") {
+		t.Errorf("unexpected catch output:\n%s", out)
+	}
+}
+
+func TestMovesAndMove(t *testing.T) {
+	conf := newFixtureConfig(t)
+	ctx := context.Background()
+	var result movesResult
+	out := captureOutput(t, func() error { return runLine(ctx, conf, "moves pikachu -o json") })
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("moves -o json is not JSON: %v\n%s", err, out)
+	}
+	var names []string
+	for _, m := range result.Moves {
+		names = append(names, m.Move)
+	}
+	want := "thunder-shock,growl,tail-whip,quick-attack,thunderbolt,thunder,iron-tail,thunder-wave,present,reversal,surf"
+	if result.VersionGroup != "diamond-pearl" || strings.Join(names, ",") != want {
+		t.Errorf("moves pikachu listed %v in %s, want %s", names, result.VersionGroup, want)
+	}
+
+	out = captureOutput(t, func() error {
+		return runLine(ctx, conf, "moves pikachu --method egg --version-group firered-leafgreen -o table")
+	})
+	if !strings.Contains(out, "wish") || strings.Contains(out, "thunder") {
+		t.Errorf("unexpected egg moves:\n%s", out)
+	}
+	for _, line := range []string{"moves pikachu --method trade", "moves pikachu --version-group gold-silver"} {
+		if err := runLine(ctx, conf, line); err == nil {
+			t.Errorf("%s: expected an error", line)
+		}
+	}
+
+	out = captureOutput(t, func() error { return runLine(ctx, conf, "move iron-tail") })
+	want = "iron-tail\nType: steel\nClass: physical\nPower: 100\nAccuracy: 75%\nPP: 15\n" +
+		"Inflicts regular damage. Has a 30% chance to lower the target's Defense by one stage.\n"
+	if out != want {
+		t.Errorf("move output:\n%s\nwant:\n%s", out, want)
+	}
+}
+
+func TestAbilities(t *testing.T) {
+	conf := newFixtureConfig(t)
+	ctx := context.Background()
+	conf.Pokedex.AddItem("master-ball", 1)
+	meet(conf, "pikachu", 10)
+	captureOutput(t, func() error { return runLine(ctx, conf, "catch --ball master") })
+	caught, ok := conf.Pokedex.Get(1)
+	if !ok || (caught.Ability != "static" && caught.Ability != "lightning-rod") {
+		t.Fatalf("unexpected caught pokemon %+v", caught)
+	}
+
+	out := captureOutput(t, func() error { return runLine(ctx, conf, "inspect pikachu") })
+	if !strings.HasSuffix(out, "Abilities:\n  - static\n  - lightning-rod (hidden)\n") {
+		t.Errorf("inspect output does not end with the abilities:\n%s", out)
+	}
+	caught.Ability = "lightning-rod"
+	out = captureOutput(t, func() error { return runLine(ctx, conf, "inspect 1") })
+	if !strings.Contains(out, "Ability: lightning-rod (hidden)\n") {
+		t.Errorf("inspect 1 output:\n%s", out)
+	}
+
+	out = captureOutput(t, func() error { return runLine(ctx, conf, "ability run-away") })
+	want := "run-away\nThis Pokémon is always able to flee from wild battles.\n" +
+		"Pokemon:\n  - caterpie (hidden)\n  - weedle (hidden)\n  - rattata\n  - raticate\n"
+	if out != want {
+		t.Errorf("ability output:\n%s\nwant:\n%s", out, want)
+	}
+
+	conf.Pokedex.AddItem("thunder-stone", 1)
+	captureOutput(t, func() error { return runLine(ctx, conf, "evolve 1") })
+	if caught.Name != "raichu" || caught.Ability != "lightning-rod" {
+		t.Errorf("expected a raichu with lightning-rod, got %+v", caught)
+	}
+}
+
+func TestPrefetchCachesEveryCommand(t *testing.T) {
+	conf := newFixtureConfig(t)
+	ctx := context.Background()
+	disk, err := diskcache.Open(t.TempDir(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conf.Client = pokeapi.NewClient(pokeapi.WithBaseURL(conf.Client.BaseURL()), pokeapi.WithDiskCache(disk))
+	var result prefetchResult
+	out := captureOutput(t, func() error { return runLine(ctx, conf, "prefetch -o json") })
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("prefetch -o json is not JSON: %v\n%s", err, out)
+	}
+	if result.Failed != 0 || result.Pokemon == 0 || result.Species == 0 || result.Moves == 0 || result.Abilities == 0 {
+		t.Errorf("unexpected prefetch result %+v", result)
+	}
+
+	conf.Client = pokeapi.NewClient(pokeapi.WithBaseURL(conf.Client.BaseURL()), pokeapi.WithDiskCache(disk), pokeapi.WithOffline(true))
+	conf.Pokedex.AddItem("master-ball", 1)
+	meet(conf, "pikachu", 10)
+	lines := []string{"catch --ball master", "inspect pikachu", "inspect 1", "evolution pikachu", "moves pikachu",
+		"move thunderbolt", "ability static", "types electric", "matchup 1 1", "explore kanto-route-1-area", "inspect 1 --lang ja"}
+	for _, line := range lines {
+		// captureOutput fails the test for commands that need uncached resources.
+		captureOutput(t, func() error { return runLine(ctx, conf, line) })
+	}
+}
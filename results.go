@@ -0,0 +1,1028 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/anegri01f01/pokegocli/internal/battle"
+	"github.com/anegri01f01/pokegocli/internal/i18n"
+	"github.com/anegri01f01/pokegocli/internal/stats"
+)
+
+// The result types below are the stable output schema of each command
+// for --output json and yaml. Text and Table give the human renderings.
+
+// labels maps PokeAPI resources, written resource/slug like
+// "type/electric", to their names in the chosen language.
+type labels map[string]string
+
+// of returns the localized name of a resource, or its slug.
+func (l labels) of(resource, slug string) string {
+	if name, ok := l[resource+"/"+slug]; ok {
+		return name
+	}
+	return slug
+}
+
+// with returns the localized name of a resource followed by the slug to
+// type in commands, or just the slug.
+func (l labels) with(resource, slug string) string {
+	if name := l.of(resource, slug); name != slug {
+		return name + " (" + slug + ")"
+	}
+	return slug
+}
+
+type messageResult struct {
+	Message string `json:"message"`
+}
+
+func (r messageResult) Text(w io.Writer) error {
+	_, err := fmt.Fprintln(w, r.Message)
+	return err
+}
+
+type helpResult struct {
+	Commands []helpEntry `json:"commands"`
+	// Single is set when help was asked about one command.
+	Single bool `json:"-"`
+	lang   string
+}
+
+type helpEntry struct {
+	Name        string       `json:"name"`
+	Usage       string       `json:"usage"`
+	Description string       `json:"description"`
+	Options     []optionHelp `json:"options,omitempty"`
+}
+
+type optionHelp struct {
+	Name  string `json:"name"`
+	Usage string `json:"usage"`
+}
+
+func (r helpResult) Text(w io.Writer) error {
+	if !r.Single {
+		fmt.Fprintf(w, "%s\n%s\n\n", i18n.T(r.lang, "Welcome to the Pokedex!"), i18n.T(r.lang, "Usage:"))
+	}
+	for _, cmd := range r.Commands {
+		fmt.Fprintf(w, "%s: %s\n", cmd.Usage, cmd.Description)
+		for _, opt := range cmd.Options {
+			fmt.Fprintf(w, "    %-24s %s\n", opt.Name, opt.Usage)
+		}
+	}
+	if !r.Single {
+		fmt.Fprintln(w, "\n"+i18n.T(r.lang, "Every command also accepts:"))
+		for _, f := range commonFlags {
+			fmt.Fprintf(w, "    %-24s %s\n", f.usageName(), f.usage)
+		}
+	}
+	return nil
+}
+
+func (r helpResult) Table() ([]string, [][]string) {
+	rows := make([][]string, 0, len(r.Commands))
+	for _, cmd := range r.Commands {
+		rows = append(rows, []string{cmd.Usage, cmd.Description})
+	}
+	return []string{"usage", "description"}, rows
+}
+
+type areaPage struct {
+	Areas       []string `json:"areas"`
+	HasNext     bool     `json:"has_next"`
+	HasPrevious bool     `json:"has_previous"`
+	Labels      labels   `json:"labels,omitempty"`
+}
+
+func (r areaPage) Text(w io.Writer) error {
+	for _, area := range r.Areas {
+		fmt.Fprintln(w, r.Labels.with("location-area", area))
+	}
+	return nil
+}
+
+func (r areaPage) Table() ([]string, [][]string) {
+	rows := make([][]string, 0, len(r.Areas))
+	for _, area := range r.Areas {
+		rows = append(rows, []string{area})
+	}
+	return []string{"area"}, rows
+}
+
+type exploreResult struct {
+	Area    string `json:"area"`
+	Version string `json:"version"`
+	// Method is the encounter method the encounter command will use.
+	Method string `json:"method"`
+	// Pokemon lists each Pokemon of Encounters once.
+	Pokemon     []string        `json:"pokemon"`
+	Encounters  []encounterSlot `json:"encounters"`
+	MethodRates []methodRate    `json:"method_rates"`
+	// Found lists the balls picked up on the way.
+	Found  []itemCount `json:"found"`
+	Labels labels      `json:"labels,omitempty"`
+	lang   string
+}
+
+type encounterSlot struct {
+	Pokemon string `json:"pokemon"`
+	Method  string `json:"method"`
+	// Chance is the percentage of encounters with Method that are this
+	// Pokemon, summed over PokeAPI's entries for it.
+	Chance   int `json:"chance"`
+	MinLevel int `json:"min_level"`
+	MaxLevel int `json:"max_level"`
+}
+
+func (s encounterSlot) levels() string {
+	if s.MinLevel == s.MaxLevel {
+		return "Lv. " + strconv.Itoa(s.MinLevel)
+	}
+	return "Lv. " + strconv.Itoa(s.MinLevel) + "-" + strconv.Itoa(s.MaxLevel)
+}
+
+type methodRate struct {
+	Method string `json:"method"`
+	Rate   int    `json:"rate"`
+}
+
+func (r exploreResult) Text(w io.Writer) error {
+	fmt.Fprintln(w, i18n.Sprintf(r.lang, "Exploring %s (%s)...", r.Labels.of("location-area", r.Area), r.Version))
+	fmt.Fprintln(w, i18n.T(r.lang, "Found Pokemon:"))
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, slot := range r.Encounters {
+		fmt.Fprintf(tw, " - %s\t%s\t%d%%\t%s\n", r.Labels.with("pokemon", slot.Pokemon), slot.Method, slot.Chance, slot.levels())
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if len(r.MethodRates) > 0 {
+		rates := make([]string, 0, len(r.MethodRates))
+		for _, rate := range r.MethodRates {
+			rates = append(rates, rate.Method+" "+strconv.Itoa(rate.Rate)+"%")
+		}
+		fmt.Fprintln(w, i18n.Sprintf(r.lang, "Encounter rates: %s", strings.Join(rates, ", ")))
+	}
+	fmt.Fprintln(w, i18n.Sprintf(r.lang, "Looking for pokemon by %s", r.Method))
+	for _, item := range r.Found {
+		fmt.Fprintln(w, i18n.Sprintf(r.lang, "You found %d %s!", item.Count, plural(r.lang, i18n.Title(item.Item), item.Count)))
+	}
+	return nil
+}
+
+func (r exploreResult) Table() ([]string, [][]string) {
+	rows := make([][]string, 0, len(r.Encounters))
+	for _, slot := range r.Encounters {
+		rows = append(rows, []string{slot.Pokemon, slot.Method, strconv.Itoa(slot.Chance) + "%", slot.levels()})
+	}
+	return []string{"pokemon", "method", "chance", "levels"}, rows
+}
+
+type encounterResult struct {
+	Area    string `json:"area"`
+	Version string `json:"version"`
+	Method  string `json:"method"`
+	Pokemon string `json:"pokemon"`
+	Level   int    `json:"level"`
+	lang    string
+}
+
+func (r encounterResult) Text(w io.Writer) error {
+	_, err := fmt.Fprintln(w, i18n.Sprintf(r.lang, "A wild %s (Lv. %d) appeared!", r.Pokemon, r.Level))
+	return err
+}
+
+type catchResult struct {
+	// ID is the caught Pokemon's ID, 0 if it escaped.
+	ID      int    `json:"id,omitempty"`
+	Pokemon string `json:"pokemon"`
+	Level   int    `json:"level"`
+	Caught  bool   `json:"caught"`
+	Ball    string `json:"ball"`
+	// BallsLeft is how many of the ball remain after the throw.
+	BallsLeft int `json:"balls_left"`
+	// Chance is the probability the throw had, from 0 to 1.
+	Chance float64 `json:"chance"`
+	// Shakes counts the shake checks passed, 4 for a catch.
+	Shakes int `json:"shakes"`
+	// Battle is the wild Pokemon's turn when it broke free in battle.
+	Battle []string `json:"battle,omitempty"`
+	lang   string
+}
+
+func (r catchResult) Text(w io.Writer) error {
+	line := func(format string, args ...any) { fmt.Fprintln(w, i18n.Sprintf(r.lang, format, args...)) }
+	line("%s has a %.1f%% chance to be caught", r.Pokemon, r.Chance*100)
+	label := i18n.Title(r.Ball)
+	line("Throwing %s at %s...", withArticle(r.lang, label), r.Pokemon)
+	if r.Shakes > 0 && !r.Caught {
+		line("The ball shook %d time(s)...", r.Shakes)
+	}
+	if r.Caught {
+		line("%s was caught! It is #%d in your list", r.Pokemon, r.ID)
+	} else {
+		line("%s escaped!", r.Pokemon)
+	}
+	line("%s left: %d", plural(r.lang, label, 2), r.BallsLeft)
+	for _, line := range r.Battle {
+		fmt.Fprintln(w, line)
+	}
+	return nil
+}
+
+type inspectResult struct {
+	Name string `json:"name"`
+	// Height is in decimetres and Weight in hectograms, as PokeAPI has
+	// them.
+	Height int `json:"height"`
+	Weight int `json:"weight"`
+	measures
+	Genus string `json:"genus"`
+	// FlavorText is the Pokedex entry of FlavorVersion.
+	FlavorText    string   `json:"flavor_text"`
+	FlavorVersion string   `json:"flavor_version"`
+	Generation    string   `json:"generation"`
+	Habitat       string   `json:"habitat,omitempty"`
+	Color         string   `json:"color"`
+	EggGroups     []string `json:"egg_groups"`
+	// GenderRatio is absent for genderless species.
+	GenderRatio *genderSplit  `json:"gender_ratio,omitempty"`
+	CaptureRate int           `json:"capture_rate"`
+	Stats       []statValue   `json:"stats"`
+	Types       []string      `json:"types"`
+	Abilities   []abilitySlot `json:"abilities"`
+	Labels      labels        `json:"labels,omitempty"`
+	lang        string
+}
+
+// abilitySlot is one of a species' abilities. Hidden abilities take slot 3.
+type abilitySlot struct {
+	Name   string `json:"name"`
+	Hidden bool   `json:"hidden"`
+	Slot   int    `json:"slot"`
+}
+
+type measures struct {
+	HeightM  float64 `json:"height_m"`
+	HeightFt int     `json:"height_ft"`
+	HeightIn int     `json:"height_in"`
+	WeightKg float64 `json:"weight_kg"`
+	WeightLb float64 `json:"weight_lb"`
+}
+
+func (m measures) height() string {
+	return fmt.Sprintf("%.1f m (%d'%02d\")", m.HeightM, m.HeightFt, m.HeightIn)
+}
+
+func (m measures) weight() string {
+	return fmt.Sprintf("%.1f kg (%.1f lbs)", m.WeightKg, m.WeightLb)
+}
+
+// genderSplit is the percentage of each gender.
+type genderSplit struct {
+	Male   float64 `json:"male"`
+	Female float64 `json:"female"`
+}
+
+func (g *genderSplit) text(lang string) string {
+	if g == nil {
+		return i18n.T(lang, "genderless")
+	}
+	format := func(p float64) string { return strconv.FormatFloat(p, 'f', -1, 64) + "%" }
+	return i18n.Sprintf(lang, "%s male, %s female", format(g.Male), format(g.Female))
+}
+
+type statValue struct {
+	Name  string `json:"name"`
+	Value int    `json:"value"`
+}
+
+func (r inspectResult) Text(w io.Writer) error {
+	line := func(format string, args ...any) { fmt.Fprintln(w, i18n.Sprintf(r.lang, format, args...)) }
+	line("Name: %s", r.Labels.of("pokemon", r.Name))
+	if r.Genus != "" {
+		line("Genus: %s", r.Genus)
+	}
+	line("Height: %s", r.height())
+	line("Weight: %s", r.weight())
+	line("Generation: %s", r.Generation)
+	if r.Habitat != "" {
+		line("Habitat: %s", r.Habitat)
+	}
+	line("Color: %s", r.Color)
+	line("Egg groups: %s", strings.Join(r.EggGroups, ", "))
+	line("Gender: %s", r.GenderRatio.text(r.lang))
+	line("Capture rate: %d", r.CaptureRate)
+	if r.FlavorText != "" {
+		line("Pokedex (%s): %s", r.FlavorVersion, r.FlavorText)
+	}
+	line("Stats:")
+	for _, stat := range r.Stats {
+		fmt.Fprintln(w, "  -"+r.Labels.of("stat", stat.Name)+": "+strconv.Itoa(stat.Value))
+	}
+	line("Types:")
+	for _, typ := range r.Types {
+		fmt.Fprintln(w, "  - "+r.Labels.of("type", typ))
+	}
+	line("Abilities:")
+	for _, a := range r.Abilities {
+		name := r.Labels.with("ability", a.Name)
+		if a.Hidden {
+			name += " (" + i18n.T(r.lang, "hidden") + ")"
+		}
+		fmt.Fprintln(w, "  - "+name)
+	}
+	return nil
+}
+
+func (r inspectResult) Table() ([]string, [][]string) {
+	rows := [][]string{
+		{"name", r.Name},
+		{"genus", r.Genus},
+		{"height", r.height()},
+		{"weight", r.weight()},
+		{"generation", r.Generation},
+		{"habitat", r.Habitat},
+		{"color", r.Color},
+		{"egg groups", strings.Join(r.EggGroups, ", ")},
+		{"gender", r.GenderRatio.text(i18n.English)},
+		{"capture rate", strconv.Itoa(r.CaptureRate)},
+	}
+	for _, stat := range r.Stats {
+		rows = append(rows, []string{stat.Name, strconv.Itoa(stat.Value)})
+	}
+	for i, typ := range r.Types {
+		rows = append(rows, []string{"type " + strconv.Itoa(i+1), typ})
+	}
+	for _, a := range r.Abilities {
+		label := "ability " + strconv.Itoa(a.Slot)
+		if a.Hidden {
+			label = "hidden ability"
+		}
+		rows = append(rows, []string{label, a.Name})
+	}
+	return []string{"field", "value"}, rows
+}
+
+type pokedexResult struct {
+	Pokemon []string `json:"pokemon"`
+	Labels  labels   `json:"labels,omitempty"`
+	lang    string
+}
+
+func (r pokedexResult) Text(w io.Writer) error {
+	if len(r.Pokemon) == 0 {
+		fmt.Fprintln(w, i18n.T(r.lang, "Your Pokedex is empty"))
+	}
+	for _, name := range r.Pokemon {
+		fmt.Fprintln(w, "  - "+r.Labels.with("pokemon", name))
+	}
+	return nil
+}
+
+func (r pokedexResult) Table() ([]string, [][]string) {
+	rows := make([][]string, 0, len(r.Pokemon))
+	for _, name := range r.Pokemon {
+		rows = append(rows, []string{name})
+	}
+	return []string{"pokemon"}, rows
+}
+
+type cacheStatsResult struct {
+	MemoryEntries int   `json:"memory_entries"`
+	MemoryHits    int   `json:"memory_hits"`
+	DiskHits      int   `json:"disk_hits"`
+	Fetches       int   `json:"network_fetches"`
+	DiskEnabled   bool  `json:"disk_enabled"`
+	DiskEntries   int   `json:"disk_entries"`
+	DiskBytes     int64 `json:"disk_bytes"`
+	DiskMaxBytes  int64 `json:"disk_max_bytes"`
+	DiskEvictions int   `json:"disk_evictions"`
+	lang          string
+}
+
+func (r cacheStatsResult) Text(w io.Writer) error {
+	line := func(format string, args ...any) { fmt.Fprintln(w, i18n.Sprintf(r.lang, format, args...)) }
+	line("Memory entries: %d", r.MemoryEntries)
+	line("Memory hits: %d", r.MemoryHits)
+	line("Disk hits: %d", r.DiskHits)
+	line("Network fetches: %d", r.Fetches)
+	if !r.DiskEnabled {
+		line("Disk cache: disabled")
+		return nil
+	}
+	line("Disk entries: %d", r.DiskEntries)
+	line("Disk size: %.1f / %.1f MiB", float64(r.DiskBytes)/(1<<20), float64(r.DiskMaxBytes)/(1<<20))
+	line("Disk evictions: %d", r.DiskEvictions)
+	return nil
+}
+
+type prefetchResult struct {
+	LocationAreas int `json:"location_areas"`
+	Pokemon       int `json:"pokemon"`
+	Species       int `json:"species"`
+	Moves         int `json:"moves"`
+	Abilities     int `json:"abilities"`
+	Types         int `json:"types"`
+	Stats         int `json:"stats"`
+	Failed        int `json:"failed"`
+	// targets are the prefetch targets fetched.
+	targets []prefetchTarget
+	lang    string
+}
+
+func (r prefetchResult) Text(w io.Writer) error {
+	var parts []string
+	for _, target := range r.targets {
+		parts = append(parts, strconv.Itoa(*target.count(&r))+" "+i18n.T(r.lang, target.label))
+	}
+	fmt.Fprintln(w, i18n.Sprintf(r.lang, "Prefetched %s", strings.Join(parts, ", ")))
+	if r.Failed > 0 {
+		fmt.Fprintln(w, i18n.Sprintf(r.lang, "Could not fetch %d resources", r.Failed))
+	}
+	return nil
+}
+
+type variablesResult struct {
+	Variables map[string]string `json:"variables"`
+}
+
+func (r variablesResult) Table() ([]string, [][]string) {
+	rows := make([][]string, 0, len(r.Variables))
+	for _, name := range sortedKeys(r.Variables) {
+		rows = append(rows, []string{name, r.Variables[name]})
+	}
+	return []string{"name", "value"}, rows
+}
+
+func (r variablesResult) Text(w io.Writer) error {
+	for _, name := range sortedKeys(r.Variables) {
+		fmt.Fprintln(w, name+"="+r.Variables[name])
+	}
+	return nil
+}
+
+type itemCount struct {
+	Item        string `json:"item"`
+	Count       int    `json:"count"`
+	Description string `json:"description,omitempty"`
+}
+
+type inventoryResult struct {
+	Items []itemCount `json:"items"`
+	lang  string
+}
+
+func (r inventoryResult) Text(w io.Writer) error {
+	if len(r.Items) == 0 {
+		fmt.Fprintln(w, i18n.T(r.lang, "Your bag is empty, explore to find balls"))
+		return nil
+	}
+	for _, item := range r.Items {
+		fmt.Fprintf(w, "  - %s x%d: %s\n", i18n.Title(item.Item), item.Count, i18n.T(r.lang, item.Description))
+	}
+	return nil
+}
+
+func (r inventoryResult) Table() ([]string, [][]string) {
+	rows := make([][]string, 0, len(r.Items))
+	for _, item := range r.Items {
+		rows = append(rows, []string{item.Item, strconv.Itoa(item.Count), item.Description})
+	}
+	return []string{"item", "count", "description"}, rows
+}
+
+// article returns "a" or "an" for the word that follows.
+func article(word string) string {
+	if word != "" && strings.ContainsRune("AEIOUaeiou", rune(word[0])) {
+		return "an"
+	}
+	return "a"
+}
+
+// withArticle puts "a" or "an" before an item's label in English. The
+// translations take the bare label.
+func withArticle(lang, label string) string {
+	if i18n.Translated(lang) {
+		return label
+	}
+	return article(label) + " " + label
+}
+
+// plural adds an English plural "s" to an item's label when there is more
+// than one. The translations take the bare label.
+func plural(lang, label string, n int) string {
+	if n == 1 || i18n.Translated(lang) {
+		return label
+	}
+	return label + "s"
+}
+
+type caughtSummary struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Nickname string `json:"nickname,omitempty"`
+	Level    int    `json:"level"`
+	Nature   string `json:"nature"`
+	Gender   string `json:"gender"`
+	Shiny    bool   `json:"shiny"`
+}
+
+func (c caughtSummary) title(lang string) string {
+	title := "#" + strconv.Itoa(c.ID) + " " + c.Name
+	if c.Nickname != "" {
+		title = "#" + strconv.Itoa(c.ID) + " " + c.Nickname + " (" + c.Name + ")"
+	}
+	title += " Lv. " + strconv.Itoa(c.Level)
+	if c.Gender != "" {
+		title += " " + i18n.T(lang, c.Gender)
+	}
+	if c.Shiny {
+		title += " " + i18n.T(lang, "shiny")
+	}
+	return title
+}
+
+type listResult struct {
+	Pokemon []caughtSummary `json:"pokemon"`
+	lang    string
+}
+
+func (r listResult) Text(w io.Writer) error {
+	if len(r.Pokemon) == 0 {
+		fmt.Fprintln(w, i18n.T(r.lang, "You have not caught any pokemon yet"))
+	}
+	for _, c := range r.Pokemon {
+		fmt.Fprintln(w, "  "+c.title(r.lang))
+	}
+	return nil
+}
+
+func (r listResult) Table() ([]string, [][]string) {
+	rows := make([][]string, 0, len(r.Pokemon))
+	for _, c := range r.Pokemon {
+		rows = append(rows, []string{
+			strconv.Itoa(c.ID), c.Name, c.Nickname, strconv.Itoa(c.Level), c.Gender, c.Nature, strconv.FormatBool(c.Shiny),
+		})
+	}
+	return []string{"id", "name", "nickname", "level", "gender", "nature", "shiny"}, rows
+}
+
+type caughtResult struct {
+	caughtSummary
+	Ability string `json:"ability,omitempty"`
+	// HiddenAbility tells whether Ability is the species' hidden ability.
+	HiddenAbility bool      `json:"hidden_ability"`
+	Friendship    int       `json:"friendship"`
+	Ball          string    `json:"ball,omitempty"`
+	CaughtAt      time.Time `json:"caught_at"`
+	Location      string    `json:"location,omitempty"`
+	// IVs are the individual values and Stats the actual stats at Level.
+	IVs    stats.Stats `json:"ivs"`
+	Stats  stats.Stats `json:"stats"`
+	Types  []string    `json:"types"`
+	Labels labels      `json:"labels,omitempty"`
+	lang   string
+}
+
+func (r caughtResult) Text(w io.Writer) error {
+	line := func(format string, args ...any) { fmt.Fprintln(w, i18n.Sprintf(r.lang, format, args...)) }
+	fmt.Fprintln(w, r.title(r.lang))
+	line("Nature: %s", r.Nature)
+	if r.Ability != "" {
+		ability := r.Labels.with("ability", r.Ability)
+		if r.HiddenAbility {
+			ability += " (" + i18n.T(r.lang, "hidden") + ")"
+		}
+		line("Ability: %s", ability)
+	}
+	line("Friendship: %d", r.Friendship)
+	if !r.CaughtAt.IsZero() {
+		when := r.CaughtAt.Local().Format("2006-01-02 15:04")
+		ball := withArticle(r.lang, i18n.Title(r.Ball))
+		switch {
+		case r.Location != "" && r.Ball != "":
+			line("Caught: %s in %s with %s", when, r.Location, ball)
+		case r.Location != "":
+			line("Caught: %s in %s", when, r.Location)
+		case r.Ball != "":
+			line("Caught: %s with %s", when, ball)
+		default:
+			line("Caught: %s", when)
+		}
+	}
+	line("Stats:")
+	for _, name := range stats.Names {
+		fmt.Fprintf(w, "  -%s: %d (IV %d)\n", r.Labels.of("stat", name), r.Stats.Get(name), r.IVs.Get(name))
+	}
+	line("Types:")
+	for _, typ := range r.Types {
+		fmt.Fprintln(w, "  - "+r.Labels.of("type", typ))
+	}
+	return nil
+}
+
+type battleResult struct {
+	Kind    string `json:"kind"`
+	Trainer string `json:"trainer,omitempty"`
+	// Log is what happened since the last command.
+	Log      []string    `json:"log"`
+	Player   combatant   `json:"player"`
+	Opponent combatant   `json:"opponent"`
+	Team     []combatant `json:"team"`
+	Turns    int         `json:"turns"`
+	// Outcome is won, lost or fled once the battle is over.
+	Outcome string `json:"outcome,omitempty"`
+	lang    string
+}
+
+// combatant is a Pokemon in battle as shown to the player.
+type combatant struct {
+	ID       int      `json:"id,omitempty"`
+	Name     string   `json:"name"`
+	Nickname string   `json:"nickname,omitempty"`
+	Level    int      `json:"level"`
+	HP       int      `json:"hp"`
+	MaxHP    int      `json:"max_hp"`
+	Moves    []string `json:"moves"`
+}
+
+func newCombatant(f *battle.Fighter) combatant {
+	c := combatant{ID: f.ID, Name: f.Name, Nickname: f.Nickname, Level: f.Level, HP: f.HP, MaxHP: f.Stats.HP, Moves: []string{}}
+	for _, m := range f.Moves {
+		c.Moves = append(c.Moves, m.Name)
+	}
+	return c
+}
+
+func newBattleResult(config *Config, b *battle.Battle, log []string) battleResult {
+	r := battleResult{
+		lang:     language(config),
+		Kind:     b.Kind,
+		Trainer:  b.Trainer,
+		Log:      append([]string{}, log...),
+		Player:   newCombatant(b.Player.Fighter()),
+		Opponent: newCombatant(b.Opponent.Fighter()),
+		Turns:    b.Turns,
+		Outcome:  b.Outcome,
+	}
+	for _, f := range b.Player.Team {
+		r.Team = append(r.Team, newCombatant(f))
+	}
+	return r
+}
+
+func (c combatant) title() string {
+	if c.Nickname != "" {
+		return c.Nickname + " (" + c.Name + ")"
+	}
+	return c.Name
+}
+
+// status is the combatant's level and HP after its name as shown.
+func (c combatant) status(name string) string {
+	return fmt.Sprintf("%s Lv. %d\tHP %d/%d", name, c.Level, c.HP, c.MaxHP)
+}
+
+func (r battleResult) Text(w io.Writer) error {
+	for _, line := range r.Log {
+		fmt.Fprintln(w, line)
+	}
+	if r.Outcome != "" {
+		return nil
+	}
+	foe := i18n.Sprintf(r.lang, "Wild %s", r.Opponent.title())
+	if r.Kind == battle.Trainer {
+		foe = i18n.Sprintf(r.lang, "%s's %s", i18n.T(r.lang, r.Trainer), r.Opponent.title())
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, r.Opponent.status(foe))
+	fmt.Fprintln(tw, r.Player.status(r.Player.title()))
+	tw.Flush()
+
+	moves := make([]string, len(r.Player.Moves))
+	for i, m := range r.Player.Moves {
+		moves[i] = strconv.Itoa(i+1) + " " + m
+	}
+	fmt.Fprintln(w, i18n.Sprintf(r.lang, "Moves: %s", strings.Join(moves, ", ")))
+	if len(r.Team) > 1 {
+		team := make([]string, len(r.Team))
+		for i, c := range r.Team {
+			name := c.Name
+			if c.Nickname != "" {
+				name = c.Nickname
+			}
+			team[i] = fmt.Sprintf("#%d %s %d/%d", c.ID, name, c.HP, c.MaxHP)
+		}
+		fmt.Fprintln(w, i18n.Sprintf(r.lang, "Team: %s", strings.Join(team, ", ")))
+	}
+	return nil
+}
+
+type typeMatchup struct {
+	Type       string  `json:"type"`
+	Multiplier float64 `json:"multiplier"`
+}
+
+func (m typeMatchup) String() string {
+	return m.Type + " x" + strconv.FormatFloat(m.Multiplier, 'g', -1, 64)
+}
+
+func joinMatchups(lang string, matchups []typeMatchup) string {
+	if len(matchups) == 0 {
+		return i18n.T(lang, "none")
+	}
+	parts := make([]string, len(matchups))
+	for i, m := range matchups {
+		parts[i] = m.String()
+	}
+	return strings.Join(parts, ", ")
+}
+
+type typesResult struct {
+	Types       []string      `json:"types"`
+	Weaknesses  []typeMatchup `json:"weaknesses"`
+	Resistances []typeMatchup `json:"resistances"`
+	Immunities  []string      `json:"immunities"`
+	lang        string
+}
+
+func (r typesResult) Text(w io.Writer) error {
+	line := func(format string, args ...any) { fmt.Fprintln(w, i18n.Sprintf(r.lang, format, args...)) }
+	immunities := i18n.T(r.lang, "none")
+	if len(r.Immunities) > 0 {
+		immunities = strings.Join(r.Immunities, ", ")
+	}
+	fmt.Fprintln(w, strings.Join(r.Types, "/"))
+	line("Weak to: %s", joinMatchups(r.lang, r.Weaknesses))
+	line("Resists: %s", joinMatchups(r.lang, r.Resistances))
+	line("Immune to: %s", immunities)
+	return nil
+}
+
+// matchupSide is a caught Pokemon, or a caught species when ID is 0.
+type matchupSide struct {
+	ID       int      `json:"id,omitempty"`
+	Name     string   `json:"name"`
+	Nickname string   `json:"nickname,omitempty"`
+	Types    []string `json:"types"`
+}
+
+func (s matchupSide) title() string {
+	title := s.Name
+	if s.Nickname != "" {
+		title = s.Nickname + " (" + s.Name + ")"
+	}
+	if s.ID != 0 {
+		title = "#" + strconv.Itoa(s.ID) + " " + title
+	}
+	return title
+}
+
+type moveMatchup struct {
+	Move       string  `json:"move"`
+	Type       string  `json:"type"`
+	Class      string  `json:"class"`
+	Multiplier float64 `json:"multiplier"`
+}
+
+type matchupResult struct {
+	Attacker matchupSide `json:"attacker"`
+	Defender matchupSide `json:"defender"`
+	// Attack holds the attacker's types against the defender, Defend the
+	// defender's types against the attacker.
+	Attack []typeMatchup `json:"attack"`
+	Defend []typeMatchup `json:"defend"`
+	// Moves are the attacker's moves against the defender, for a caught
+	// Pokemon.
+	Moves []moveMatchup `json:"moves"`
+	lang  string
+}
+
+func (r matchupResult) Text(w io.Writer) error {
+	line := func(format string, args ...any) { fmt.Fprintln(w, i18n.Sprintf(r.lang, format, args...)) }
+	fmt.Fprintf(w, "%s (%s) vs %s (%s)\n", r.Attacker.title(), strings.Join(r.Attacker.Types, "/"), r.Defender.title(), strings.Join(r.Defender.Types, "/"))
+	line("Attacking: %s", joinMatchups(r.lang, r.Attack))
+	line("Defending: %s", joinMatchups(r.lang, r.Defend))
+	if len(r.Moves) > 0 {
+		line("Moves:")
+	}
+	for _, m := range r.Moves {
+		if m.Class == battle.Status {
+			fmt.Fprintf(w, "  - %s (%s, status)\n", m.Move, m.Type)
+			continue
+		}
+		fmt.Fprintf(w, "  - %s (%s) x%s\n", m.Move, m.Type, strconv.FormatFloat(m.Multiplier, 'g', -1, 64))
+	}
+	return nil
+}
+
+type evolutionResult struct {
+	// Species is the species asked about.
+	Species string         `json:"species"`
+	Chain   evolutionStage `json:"chain"`
+	lang    string
+}
+
+type evolutionStage struct {
+	Species string `json:"species"`
+	Baby    bool   `json:"baby"`
+	// Conditions are the ways to evolve into this stage, empty for the
+	// first one.
+	Conditions []string         `json:"conditions"`
+	EvolvesTo  []evolutionStage `json:"evolves_to"`
+}
+
+func (r evolutionResult) Text(w io.Writer) error {
+	fmt.Fprintln(w, r.Chain.label(r.lang, r.Species))
+	r.Chain.writeChildren(w, r.lang, "", r.Species)
+	return nil
+}
+
+func (s evolutionStage) label(lang, current string) string {
+	label := s.Species
+	if s.Baby {
+		label += " (" + i18n.T(lang, "baby") + ")"
+	}
+	if len(s.Conditions) > 0 {
+		label += ": " + strings.Join(s.Conditions, i18n.T(lang, ", or "))
+	}
+	if s.Species == current {
+		label += " <"
+	}
+	return label
+}
+
+// writeChildren draws the stages after s as the branches of a tree.
+func (s evolutionStage) writeChildren(w io.Writer, lang, indent, current string) {
+	for i, next := range s.EvolvesTo {
+		branch, nested := "├─ ", "│  "
+		if i == len(s.EvolvesTo)-1 {
+			branch, nested = "└─ ", "   "
+		}
+		fmt.Fprintln(w, indent+branch+next.label(lang, current))
+		next.writeChildren(w, lang, indent+nested, current)
+	}
+}
+
+type evolveResult struct {
+	ID       int    `json:"id"`
+	From     string `json:"from"`
+	Into     string `json:"into"`
+	Nickname string `json:"nickname,omitempty"`
+	// Item is the item used up, if any.
+	Item string `json:"item,omitempty"`
+	lang string
+}
+
+func (r evolveResult) Text(w io.Writer) error {
+	if r.Item != "" {
+		fmt.Fprintln(w, i18n.Sprintf(r.lang, "You used %s.", withArticle(r.lang, i18n.Title(r.Item))))
+	}
+	name := r.From
+	if r.Nickname != "" {
+		name = r.Nickname
+	}
+	fmt.Fprintln(w, i18n.Sprintf(r.lang, "Congratulations! Your %s evolved into %s!", name, r.Into))
+	return nil
+}
+
+// optional writes a value PokeAPI may leave out, like the power of a
+// status move, or a dash.
+func optional(v *int, suffix string) string {
+	if v == nil {
+		return "-"
+	}
+	return strconv.Itoa(*v) + suffix
+}
+
+type movesResult struct {
+	Pokemon      string `json:"pokemon"`
+	VersionGroup string `json:"version_group"`
+	// Method is the learn method the moves were filtered by, if any.
+	Method string        `json:"method,omitempty"`
+	Moves  []learnedMove `json:"moves"`
+	Labels labels        `json:"labels,omitempty"`
+	lang   string
+}
+
+type learnedMove struct {
+	Move   string `json:"move"`
+	Method string `json:"method"`
+	// Level is 0 for moves not learned by leveling up.
+	Level    int    `json:"level"`
+	Type     string `json:"type"`
+	Class    string `json:"damage_class"`
+	Power    *int   `json:"power"`
+	Accuracy *int   `json:"accuracy"`
+	PP       int    `json:"pp"`
+}
+
+func (m learnedMove) learned() string {
+	if m.Method == "level-up" {
+		return "Lv. " + strconv.Itoa(m.Level)
+	}
+	return m.Method
+}
+
+func (r movesResult) Text(w io.Writer) error {
+	if len(r.Moves) == 0 && r.Method == "" {
+		_, err := fmt.Fprintln(w, i18n.Sprintf(r.lang, "%s learns no moves in %s", r.Pokemon, r.VersionGroup))
+		return err
+	}
+	if len(r.Moves) == 0 {
+		_, err := fmt.Fprintln(w, i18n.Sprintf(r.lang, "%s learns no %s moves in %s", r.Pokemon, r.Method, r.VersionGroup))
+		return err
+	}
+	fmt.Fprintln(w, i18n.Sprintf(r.lang, "Moves %s learns in %s:", r.Pokemon, r.VersionGroup))
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, m := range r.Moves {
+		fmt.Fprintf(tw, " - %s\t%s\t%s\t%s\t%s\t%s\t%d PP\n", m.learned(), r.Labels.with("move", m.Move), m.Type, m.Class, optional(m.Power, ""), optional(m.Accuracy, "%"), m.PP)
+	}
+	return tw.Flush()
+}
+
+func (r movesResult) Table() ([]string, [][]string) {
+	rows := make([][]string, 0, len(r.Moves))
+	for _, m := range r.Moves {
+		rows = append(rows, []string{m.learned(), m.Move, m.Type, m.Class, optional(m.Power, ""), optional(m.Accuracy, "%"), strconv.Itoa(m.PP)})
+	}
+	return []string{"learned", "move", "type", "class", "power", "accuracy", "pp"}, rows
+}
+
+type moveResult struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Class string `json:"damage_class"`
+	// Power is null for status moves and moves with no set power, and
+	// Accuracy for moves that never miss.
+	Power        *int   `json:"power"`
+	Accuracy     *int   `json:"accuracy"`
+	PP           int    `json:"pp"`
+	Priority     int    `json:"priority"`
+	EffectChance *int   `json:"effect_chance,omitempty"`
+	Effect       string `json:"effect"`
+	Labels       labels `json:"labels,omitempty"`
+	lang         string
+}
+
+func (r moveResult) Text(w io.Writer) error {
+	line := func(format string, args ...any) { fmt.Fprintln(w, i18n.Sprintf(r.lang, format, args...)) }
+	fmt.Fprintln(w, r.Labels.with("move", r.Name))
+	line("Type: %s", r.Labels.of("type", r.Type))
+	line("Class: %s", r.Class)
+	line("Power: %s", optional(r.Power, ""))
+	line("Accuracy: %s", optional(r.Accuracy, "%"))
+	line("PP: %d", r.PP)
+	if r.Priority != 0 {
+		line("Priority: %+d", r.Priority)
+	}
+	if r.Effect != "" {
+		fmt.Fprintln(w, r.Effect)
+	}
+	return nil
+}
+
+type abilityResult struct {
+	Name       string `json:"name"`
+	Generation string `json:"generation"`
+	Effect     string `json:"effect"`
+	// Pokemon lists every Pokemon that can have the ability.
+	Pokemon []abilityHolder `json:"pokemon"`
+	Labels  labels          `json:"labels,omitempty"`
+	lang    string
+}
+
+type abilityHolder struct {
+	Pokemon string `json:"pokemon"`
+	Hidden  bool   `json:"hidden"`
+}
+
+func (r abilityResult) Text(w io.Writer) error {
+	fmt.Fprintln(w, r.Labels.with("ability", r.Name))
+	if r.Effect != "" {
+		fmt.Fprintln(w, r.Effect)
+	}
+	fmt.Fprintln(w, i18n.T(r.lang, "Pokemon:"))
+	for _, p := range r.Pokemon {
+		name := p.Pokemon
+		if p.Hidden {
+			name += " (" + i18n.T(r.lang, "hidden") + ")"
+		}
+		fmt.Fprintln(w, "  - "+name)
+	}
+	return nil
+}
+
+func (r abilityResult) Table() ([]string, [][]string) {
+	rows := make([][]string, 0, len(r.Pokemon))
+	for _, p := range r.Pokemon {
+		rows = append(rows, []string{p.Pokemon, strconv.FormatBool(p.Hidden)})
+	}
+	return []string{"pokemon", "hidden"}, rows
+}
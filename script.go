@@ -0,0 +1,228 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/anegri01f01/pokegocli/internal/i18n"
+)
+
+// maxScriptDepth bounds nested source commands.
+const maxScriptDepth = 8
+
+// scriptState holds the `set -e` and `set -x` options of a running script.
+type scriptState struct {
+	path    string
+	errexit bool
+	echo    bool
+	depth   int
+}
+
+// scriptError summarizes a script whose commands failed. The individual
+// errors have already been reported with their line numbers.
+type scriptError struct {
+	path   string
+	line   int
+	failed int
+}
+
+func (e *scriptError) Error() string {
+	if e.line > 0 {
+		return fmt.Sprintf("%s: stopped at line %d", e.path, e.line)
+	}
+	return fmt.Sprintf("%s: %d command(s) failed", e.path, e.failed)
+}
+
+func commandSource(ctx context.Context, config *Config) (any, error) {
+	state := &scriptState{
+		path:    config.Args[0],
+		errexit: config.Flags["errexit"] == "true",
+		echo:    config.Flags["echo"] == "true",
+	}
+	if parent := config.script; parent != nil {
+		state.depth = parent.depth + 1
+		if state.depth >= maxScriptDepth {
+			return nil, fmt.Errorf("%s: scripts nested more than %d deep", state.path, maxScriptDepth)
+		}
+	}
+	return nil, runScript(ctx, config, state)
+}
+
+// runScript feeds every line of a script through runLine. Blank lines and
+// lines starting with # are skipped.
+func runScript(ctx context.Context, config *Config, state *scriptState) error {
+	f, err := os.Open(state.path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	parent := config.script
+	config.script = state
+	defer func() { config.script = parent }()
+
+	failed := 0
+	scanner := bufio.NewScanner(f)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if state.echo {
+			// Like a shell's trace, the echo stays out of the results on stdout.
+			fmt.Fprintln(os.Stderr, "Pokedex > "+line)
+		}
+
+		err := runLine(ctx, config, line)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err == nil {
+			continue
+		}
+		if errors.Is(err, errExit) {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "%s:%d: %s\n", state.path, lineNo, describeError(config, err))
+		failed++
+		if state.errexit {
+			return &scriptError{path: state.path, line: lineNo, failed: failed}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return &scriptError{path: state.path, failed: failed}
+	}
+	return nil
+}
+
+func commandSet(ctx context.Context, config *Config) (any, error) {
+	if len(config.Args) == 0 {
+		vars := make(map[string]string, len(config.Vars))
+		for name, value := range config.Vars {
+			vars[name] = value
+		}
+		return variablesResult{Variables: vars}, nil
+	}
+
+	switch opt := config.Args[0]; opt {
+	case "-e", "+e", "-x", "+x":
+		if len(config.Args) > 1 {
+			return nil, &usageError{cmdRegistry["set"], i18n.Sprintf(language(config), "set %s takes no value", opt)}
+		}
+		if config.script == nil {
+			return nil, errors.New("set " + opt + " only works inside scripts")
+		}
+		if opt[1] == 'e' {
+			config.script.errexit = opt[0] == '-'
+		} else {
+			config.script.echo = opt[0] == '-'
+		}
+		return nil, nil
+	}
+
+	name, value, hasValue := strings.Cut(config.Args[0], "=")
+	if !validVarName(name) {
+		return nil, fmt.Errorf("invalid variable name %q", name)
+	}
+	switch {
+	case hasValue && len(config.Args) == 1:
+	case !hasValue && len(config.Args) == 2:
+		value = config.Args[1]
+	default:
+		return nil, &usageError{cmdRegistry["set"], i18n.T(language(config), "set needs a name and a value")}
+	}
+	if config.Vars == nil {
+		config.Vars = make(map[string]string)
+	}
+	config.Vars[name] = value
+	return nil, nil
+}
+
+// expandVars replaces $name and ${name} with session variables, falling
+// back to the environment. Single-quoted text and escaped characters are
+// left alone.
+func expandVars(line string, vars map[string]string) (string, error) {
+	var out strings.Builder
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote != '\'' && c == '\\' && i+1 < len(line):
+			out.WriteString(line[i : i+2])
+			i++
+			continue
+		case quote == 0 && (c == '\'' || c == '"'):
+			quote = c
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote != '\'' && c == '$':
+			name, width := varNameAt(line[i+1:])
+			if name == "" {
+				break
+			}
+			value, ok := vars[name]
+			if !ok {
+				value, ok = os.LookupEnv(name)
+			}
+			if !ok {
+				return "", fmt.Errorf("undefined variable $%s", name)
+			}
+			out.WriteString(value)
+			i += width
+			continue
+		}
+		out.WriteByte(c)
+	}
+	return out.String(), nil
+}
+
+// varNameAt parses a variable reference following a $ and returns its
+// name and how many bytes it spans.
+func varNameAt(s string) (string, int) {
+	if strings.HasPrefix(s, "{") {
+		end := strings.IndexByte(s, '}')
+		if end < 0 || !validVarName(s[1:end]) {
+			return "", 0
+		}
+		return s[1:end], end + 1
+	}
+	n := 0
+	for n < len(s) && isVarByte(s[n], n == 0) {
+		n++
+	}
+	return s[:n], n
+}
+
+func validVarName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		if !isVarByte(name[i], i == 0) {
+			return false
+		}
+	}
+	return true
+}
+
+func isVarByte(c byte, first bool) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (!first && c >= '0' && c <= '9')
+}
+
+// sortedKeys returns the keys of m in order.
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExpandVars(t *testing.T) {
+	t.Setenv("GOKEDEX_TEST_AREA", "viridian-forest-area")
+	vars := map[string]string{"area": "kanto-route-1-area", "mon": "pidgey"}
+
+	cases := map[string]string{
+		"explore $area":              "explore kanto-route-1-area",
+		"catch ${mon}s":              "catch pidgeys",
+		`say "$mon's"`:               `say "pidgey's"`,
+		"say '$mon'":                 "say '$mon'",
+		`say \$mon`:                  `say \$mon`,
+		"explore $GOKEDEX_TEST_AREA": "explore viridian-forest-area",
+		"cost $5":                    "cost $5",
+	}
+	for in, want := range cases {
+		got, err := expandVars(in, vars)
+		if err != nil || got != want {
+			t.Errorf("expandVars(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+
+	if _, err := expandVars("explore $nowhere", vars); err == nil {
+		t.Error("expected an error for an undefined variable")
+	}
+}
+
+func writeScript(t *testing.T, lines ...string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "session.pdx")
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestRunScript(t *testing.T) {
+	conf := newFixtureConfig(t)
+	path := writeScript(t,
+		"# replay of a short session",
+		"set area kanto-route-1-area",
+		"",
+		"set -x",
+		"explore $area",
+		"explore nowhere",
+		"set +x",
+		"map",
+	)
+
+	var out string
+	var runErr error
+	trace := captureStderr(t, func() error {
+		out = captureOutput(t, func() error {
+			runErr = runLine(context.Background(), conf, "source "+path)
+			return nil
+		})
+		return nil
+	})
+
+	var scriptErr *scriptError
+	if !errors.As(runErr, &scriptErr) || scriptErr.failed != 1 || scriptErr.line != 0 {
+		t.Fatalf("expected one failed command, got %v", runErr)
+	}
+	for _, want := range []string{" - pidgey", "canalave-city-area"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	for _, want := range []string{"Pokedex > explore $area", "Pokedex > explore nowhere"} {
+		if !strings.Contains(trace, want) {
+			t.Errorf("trace on stderr missing %q:\n%s", want, trace)
+		}
+	}
+	if strings.Contains(out, "Pokedex >") || strings.Contains(trace, "Pokedex > map") {
+		t.Errorf("the trace should be on stderr until set +x:\nstdout:\n%s\nstderr:\n%s", out, trace)
+	}
+	if conf.script != nil {
+		t.Error("script state should be cleared after the script ends")
+	}
+}
+
+func TestRunScriptStopsOnError(t *testing.T) {
+	conf := newFixtureConfig(t)
+	path := writeScript(t,
+		"explore nowhere",
+		"map",
+	)
+
+	var err error
+	out := captureOutput(t, func() error {
+		err = runLine(context.Background(), conf, "run --errexit "+path)
+		return nil
+	})
+
+	var scriptErr *scriptError
+	if !errors.As(err, &scriptErr) || scriptErr.line != 1 {
+		t.Fatalf("expected the script to stop at line 1, got %v", err)
+	}
+	if strings.Contains(out, "canalave-city-area") {
+		t.Errorf("commands after the failure should not run:\n%s", out)
+	}
+}
+
+func TestRunScriptStopsOnExit(t *testing.T) {
+	conf := newFixtureConfig(t)
+	path := writeScript(t,
+		"exit",
+		"map",
+	)
+
+	var err error
+	out := captureOutput(t, func() error {
+		err = runLine(context.Background(), conf, "run "+path)
+		return nil
+	})
+	if !errors.Is(err, errExit) {
+		t.Fatalf("expected exit to stop the script and reach the caller, got %v", err)
+	}
+	if out != "" {
+		t.Errorf("commands after exit should not run:\n%s", out)
+	}
+}
+
+func TestSetOptionsOutsideScript(t *testing.T) {
+	conf := newFixtureConfig(t)
+	if err := runLine(context.Background(), conf, "set -e"); err == nil {
+		t.Error("expected set -e to fail outside a script")
+	}
+}
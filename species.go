@@ -0,0 +1,84 @@
+package main
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/anegri01f01/pokegocli/internal/i18n"
+	"github.com/anegri01f01/pokegocli/internal/pokeapi"
+)
+
+// flavorText picks the species' Pokedex entry for a game version in a
+// language. Without a version it takes the session's, or else the newest
+// entry. It returns the entry and the version it comes from.
+func flavorText(species pokeapi.PokemonSpecies, version, lang, sessionVersion string) (string, string, error) {
+	type entry struct{ text, version string }
+	var entries []entry
+	for _, l := range []string{lang, i18n.English} {
+		for _, e := range species.FlavorTextEntries {
+			if e.Language.Name == l {
+				entries = append(entries, entry{e.FlavorText, e.Version.Name})
+			}
+		}
+		if len(entries) > 0 {
+			break
+		}
+	}
+	if len(entries) == 0 {
+		return "", "", nil
+	}
+
+	find := func(v string) int {
+		return slices.IndexFunc(entries, func(e entry) bool { return e.version == v })
+	}
+	i := len(entries) - 1
+	if version != "" {
+		if i = find(version); i < 0 {
+			var versions []string
+			for _, e := range entries {
+				versions = append(versions, e.version)
+			}
+			return "", "", errors.New("no pokedex entry for " + species.Name + " in " + version + ", only " + strings.Join(versions, ", "))
+		}
+	} else if j := find(sessionVersion); j >= 0 {
+		i = j
+	}
+	// Entries keep the line breaks and form feeds of the game's text box.
+	return strings.Join(strings.Fields(entries[i].text), " "), entries[i].version, nil
+}
+
+// genus returns the species' genus in a language, or in English.
+func genus(species pokeapi.PokemonSpecies, lang string) string {
+	for _, l := range []string{lang, i18n.English} {
+		for _, g := range species.Genera {
+			if g.Language.Name == l {
+				return g.Genus
+			}
+		}
+	}
+	return ""
+}
+
+// genderRatio splits a species' gender rate, the chance of being female in
+// eighths, into percentages. It returns nil for genderless species.
+func genderRatio(rate int) *genderSplit {
+	if rate < 0 {
+		return nil
+	}
+	female := float64(rate) * 100 / 8
+	return &genderSplit{Male: 100 - female, Female: female}
+}
+
+// measurements converts PokeAPI's decimetres and hectograms.
+func measurements(height, weight int) measures {
+	inches := math.Round(float64(height) * 3.93701)
+	return measures{
+		HeightM:  float64(height) / 10,
+		HeightFt: int(inches) / 12,
+		HeightIn: int(inches) % 12,
+		WeightKg: float64(weight) / 10,
+		WeightLb: math.Round(float64(weight)*2.20462) / 10,
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/anegri01f01/pokegocli/internal/pokeapi"
+)
+
+func TestMeasurements(t *testing.T) {
+	cases := []struct {
+		height, weight int
+		want           measures
+	}{
+		{4, 60, measures{HeightM: 0.4, HeightFt: 1, HeightIn: 4, WeightKg: 6, WeightLb: 13.2}},
+		{17, 905, measures{HeightM: 1.7, HeightFt: 5, HeightIn: 7, WeightKg: 90.5, WeightLb: 199.5}},
+		// 11.8 inches round up to a whole foot.
+		{3, 1, measures{HeightM: 0.3, HeightFt: 1, HeightIn: 0, WeightKg: 0.1, WeightLb: 0.2}},
+		{88, 2100, measures{HeightM: 8.8, HeightFt: 28, HeightIn: 10, WeightKg: 210, WeightLb: 463}},
+	}
+	for _, c := range cases {
+		if got := measurements(c.height, c.weight); got != c.want {
+			t.Errorf("measurements(%d, %d) = %+v, want %+v", c.height, c.weight, got, c.want)
+		}
+	}
+}
+
+func TestGenderRatio(t *testing.T) {
+	if got := genderRatio(-1); got != nil {
+		t.Errorf("genderRatio(-1) = %+v, want nil", got)
+	}
+	cases := map[int]genderSplit{
+		0: {Male: 100, Female: 0},
+		1: {Male: 87.5, Female: 12.5},
+		8: {Male: 0, Female: 100},
+	}
+	for rate, want := range cases {
+		if got := genderRatio(rate); got == nil || *got != want {
+			t.Errorf("genderRatio(%d) = %+v, want %+v", rate, got, want)
+		}
+	}
+}
+
+func TestFlavorText(t *testing.T) {
+	species := decode[pokeapi.PokemonSpecies](t, `{"name": "pikachu", "flavor_text_entries": [
+		{"flavor_text": "It stores\nelectricity.", "language": {"name": "en"}, "version": {"name": "red"}},
+		{"flavor_text": "Il stocke\fl'électricité.", "language": {"name": "fr"}, "version": {"name": "x"}},
+		{"flavor_text": "It raises\nits tail.", "language": {"name": "en"}, "version": {"name": "x"}}
+	]}`)
+
+	cases := []struct {
+		name, version, lang, session string
+		text, from                   string
+	}{
+		{"newest entry", "", "en", "", "It raises its tail.", "x"},
+		{"session version", "", "en", "red", "It stores electricity.", "red"},
+		{"session version missing", "", "en", "gold", "It raises its tail.", "x"},
+		{"version", "red", "en", "x", "It stores electricity.", "red"},
+		{"language", "", "fr", "", "Il stocke l'électricité.", "x"},
+		{"language missing", "", "ja", "", "It raises its tail.", "x"},
+		{"language missing for version", "red", "fr", "", "", ""},
+	}
+	for _, c := range cases {
+		text, from, err := flavorText(species, c.version, c.lang, c.session)
+		if c.text == "" {
+			if err == nil {
+				t.Errorf("%s: expected an error, got %q", c.name, text)
+			}
+			continue
+		}
+		if err != nil || text != c.text || from != c.from {
+			t.Errorf("%s: flavorText = %q, %q, %v, want %q, %q", c.name, text, from, err, c.text, c.from)
+		}
+	}
+
+	if _, _, err := flavorText(species, "gold", "en", ""); err == nil {
+		t.Error("expected a version without an entry to fail")
+	}
+	if text, from, err := flavorText(decode[pokeapi.PokemonSpecies](t, `{"name": "missingno"}`), "", "en", ""); text != "" || from != "" || err != nil {
+		t.Errorf("flavorText without entries = %q, %q, %v", text, from, err)
+	}
+}
+
+func TestGenus(t *testing.T) {
+	species := decode[pokeapi.PokemonSpecies](t, `{"genera": [
+		{"genus": "Mouse Pokémon", "language": {"name": "en"}},
+		{"genus": "ねずみポケモン", "language": {"name": "ja"}}
+	]}`)
+	for lang, want := range map[string]string{"en": "Mouse Pokémon", "ja": "ねずみポケモン", "de": "Mouse Pokémon"} {
+		if got := genus(species, lang); got != want {
+			t.Errorf("genus(%s) = %q, want %q", lang, got, want)
+		}
+	}
+	if got := genus(decode[pokeapi.PokemonSpecies](t, `{}`), "en"); got != "" {
+		t.Errorf("genus without genera = %q", got)
+	}
+}
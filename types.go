@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+
+	"github.com/anegri01f01/pokegocli/internal/battle"
+	"github.com/anegri01f01/pokegocli/internal/pokeapi"
+	"github.com/anegri01f01/pokegocli/internal/typechart"
+)
+
+// typeChart loads the damage relations of every type once per session.
+// The responses are cached like any other.
+func typeChart(ctx context.Context, config *Config) (*typechart.Chart, error) {
+	if config.types != nil {
+		return config.types, nil
+	}
+	list, err := config.Client.ListAllTypes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(list))
+	for _, res := range list {
+		names = append(names, res.Name)
+	}
+
+	// Types are fetched a few at a time and kept in the list's order.
+	var mu sync.Mutex
+	fetched := make(map[string]pokeapi.Type, len(names))
+	errs := fetchAll(ctx, names, func(name string) error {
+		t, err := config.Client.GetType(ctx, name)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		fetched[name] = t
+		mu.Unlock()
+		return nil
+	}, nil)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	types := make([]pokeapi.Type, 0, len(names))
+	for _, name := range names {
+		if err, ok := errs[name]; ok {
+			return nil, err
+		}
+		types = append(types, fetched[name])
+	}
+	config.types = typechart.New(types)
+	return config.types, nil
+}
+
+func typeEffectiveness(ctx context.Context, config *Config) (battle.Effectiveness, error) {
+	chart, err := typeChart(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	return chart.Effectiveness, nil
+}
+
+func commandTypes(ctx context.Context, config *Config) (any, error) {
+	chart, err := typeChart(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	types, err := chart.Parse(config.Args[0])
+	if err != nil {
+		return nil, &usageError{cmdRegistry["types"], err.Error() + ", want one of " + strings.Join(chart.Types(), ", ")}
+	}
+
+	defense := chart.Defend(types)
+	result := typesResult{
+		Types:       types,
+		Weaknesses:  typeMatchups(defense.Weaknesses),
+		Resistances: typeMatchups(defense.Resistances),
+		Immunities:  append([]string{}, defense.Immunities...),
+		lang:        language(config),
+	}
+	return result, nil
+}
+
+func typeMatchups(matchups []typechart.Matchup) []typeMatchup {
+	out := make([]typeMatchup, 0, len(matchups))
+	for _, m := range matchups {
+		out = append(out, typeMatchup{m.Type, m.Multiplier})
+	}
+	return out
+}
+
+func commandMatchup(ctx context.Context, config *Config) (any, error) {
+	chart, err := typeChart(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	attacker, err := matchupPokemon(config, config.Args[0])
+	if err != nil {
+		return nil, err
+	}
+	defender, err := matchupPokemon(config, config.Args[1])
+	if err != nil {
+		return nil, err
+	}
+
+	result := matchupResult{
+		Attacker: attacker,
+		Defender: defender,
+		Attack:   []typeMatchup{},
+		Defend:   []typeMatchup{},
+		Moves:    []moveMatchup{},
+		lang:     language(config),
+	}
+	for _, t := range attacker.Types {
+		result.Attack = append(result.Attack, typeMatchup{t, chart.Effectiveness(t, defender.Types)})
+	}
+	for _, t := range defender.Types {
+		result.Defend = append(result.Defend, typeMatchup{t, chart.Effectiveness(t, attacker.Types)})
+	}
+	if attacker.ID == 0 {
+		return result, nil
+	}
+	caught, _ := config.Pokedex.Get(attacker.ID)
+	for _, name := range battle.LevelUpMoves(config.Pokedex.Pokemon[caught.Name], caught.Level) {
+		move, err := config.Client.GetMove(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		result.Moves = append(result.Moves, moveMatchup{
+			Move:       move.Name,
+			Type:       move.Type.Name,
+			Class:      move.DamageClass.Name,
+			Multiplier: chart.Effectiveness(move.Type.Name, defender.Types),
+		})
+	}
+	return result, nil
+}
+
+// matchupPokemon looks up a caught Pokemon by ID, or a caught species by
+// name.
+func matchupPokemon(config *Config, arg string) (matchupSide, error) {
+	side := matchupSide{Types: []string{}}
+	name := strings.ToLower(arg)
+	if id, err := parseID(arg); err == nil {
+		caught, err := caughtByID(config, arg)
+		if err != nil {
+			return side, err
+		}
+		side.ID, name = id, caught.Name
+		side.Nickname = caught.Nickname
+	}
+	data, ok := config.Pokedex.Pokemon[name]
+	if !ok {
+		return side, errors.New("you have not caught " + name + " yet")
+	}
+	side.Name = name
+	for _, t := range data.Types {
+		side.Types = append(side.Types, t.Type.Name)
+	}
+	return side, nil
+}